@@ -0,0 +1,251 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefAdaptiveMinSaving define the default minimum fraction of asset size
+// that must be saved by compression, otherwise the asset is stored
+// uncompressed.
+const DefAdaptiveMinSaving = 0.1
+
+// compressedExts contains the file extensions of formats that are already
+// compressed.
+// nolint: gochecknoglobals
+var compressedExts = map[string]struct{}{
+	".7z": {}, ".avif": {}, ".br": {}, ".bz2": {}, ".gif": {}, ".gz": {},
+	".jpeg": {}, ".jpg": {}, ".mp3": {}, ".mp4": {}, ".ogg": {},
+	".png": {}, ".tgz": {}, ".webm": {}, ".webp": {}, ".woff": {},
+	".woff2": {}, ".xz": {}, ".zip": {}, ".zst": {},
+}
+
+// compressedMagics contains the magic bytes at the beginning of formats
+// that are already compressed.
+// nolint: gochecknoglobals
+var compressedMagics = [][]byte{
+	[]byte("\x89PNG\r\n\x1a\n"),
+	[]byte("\xff\xd8\xff"),       // JPEG
+	[]byte("GIF8"),               // GIF
+	[]byte("PK\x03\x04"),         // ZIP
+	[]byte("\x1f\x8b"),           // gzip
+	[]byte("BZh"),                // bzip2
+	[]byte("\xfd7zXZ\x00"),       // xz
+	[]byte("7z\xbc\xaf\x27\x1c"), // 7-Zip
+	[]byte("\x28\xb5\x2f\xfd"),   // zstd
+	[]byte("wOFF"),               // WOFF
+	[]byte("wOF2"),               // WOFF2
+}
+
+// hasCompressedMagic return true if the head of content match one of the
+// compressed format.
+// The WebP image is detected by its RIFF header.
+func hasCompressedMagic(head []byte) bool {
+	for _, magic := range compressedMagics {
+		if bytes.HasPrefix(head, magic) {
+			return true
+		}
+	}
+	return len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) &&
+		bytes.Equal(head[8:12], []byte("WEBP"))
+}
+
+// openAsset return the reader of asset content, which is the rewritten
+// content if its set, or the file.
+func openAsset(ast *asset) (io.ReadCloser, error) {
+	if ast.content != nil {
+		return ioutil.NopCloser(bytes.NewReader(ast.content)), nil
+	}
+	return os.Open(ast.path)
+}
+
+// chooseCompression set whether the data of each asset is compressed.
+// If AdaptiveCompression is set, the decision is made per asset by
+// isCompressible, otherwise all assets are compressed unless NoCompress is
+// set.
+func chooseCompression(c *Config, keys []string, toc map[string]*asset) (err error) {
+	for _, key := range keys {
+		ast := toc[key]
+		if !c.AdaptiveCompression {
+			ast.compressed = !c.NoCompress
+			continue
+		}
+		ast.compressed, err = isCompressible(c, ast)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCompressible return true if the asset should be compressed: its not in
+// the known compressed format, by its file extension or magic bytes, and
+// the compression saves at least AdaptiveMinSaving of its size.
+func isCompressible(c *Config, ast *asset) (ok bool, err error) {
+	_, ok = compressedExts[strings.ToLower(filepath.Ext(ast.name))]
+	if ok {
+		return false, nil
+	}
+
+	fd, err := openAsset(ast)
+	if err != nil {
+		return false, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(fd, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		_ = fd.Close()
+		return false, err
+	}
+	if n == 0 || hasCompressedMagic(head[:n]) {
+		return false, fd.Close()
+	}
+
+	var (
+		counter byteCounter
+		r       = io.MultiReader(bytes.NewReader(head[:n]), fd)
+		size    int64
+	)
+
+	err = compress(&counter, c, countReader(r, &size))
+	if err != nil {
+		_ = fd.Close()
+		return false, err
+	}
+
+	err = fd.Close()
+	if err != nil {
+		return false, err
+	}
+
+	saving := 1 - float64(counter)/float64(size)
+
+	return saving >= c.AdaptiveMinSaving, nil
+}
+
+// byteCounter is the io.Writer that count the number of bytes written into
+// it.
+type byteCounter int64
+
+func (bc *byteCounter) Write(p []byte) (int, error) {
+	*bc += byteCounter(len(p))
+	return len(p), nil
+}
+
+// countReader return the reader that add the number of bytes read from r
+// into n.
+func countReader(r io.Reader, n *int64) io.Reader {
+	return readerFunc(func(p []byte) (int, error) {
+		x, err := r.Read(p)
+		*n += int64(x)
+		return x, err
+	})
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+// writeAdaptiveFunc writes the end of the asset data, and the function that
+// return its content, which pass to bindataRead whether the data is
+// compressed.
+func writeAdaptiveFunc(w io.Writer, ast *asset, end string) (err error) {
+	_, err = fmt.Fprintf(w, tmplFuncAdaptive, end, ast.funcName,
+		ast.funcName, ast.name, ast.compressed)
+	return err
+}
+
+const tmplFuncAdaptive = `%s
+
+func %sBytes() ([]byte, error) {
+	return bindataRead(
+		_%s,
+		%q,
+		%t,
+	)
+}
+
+`
+
+const tmplBindataReadAdaptiveNomemcopy = `func bindataRead(data, name string, compressed bool) ([]byte, error) {
+	if !compressed {
+		var empty [0]byte
+		sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
+		b := empty[:]
+		bx := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+		bx.Data = sx.Data
+		bx.Len = len(data)
+		bx.Cap = bx.Len
+		return b, nil
+	}
+
+	gz, err := bindataDecompress(strings.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+`
+
+const tmplBindataReadAdaptiveMemcopy = `func bindataRead(data []byte, name string, compressed bool) ([]byte, error) {
+	if !compressed {
+		return data, nil
+	}
+
+	gz, err := bindataDecompress(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+`
+
+// tmplVerifyDecompressAdaptive decompress the data of asset in
+// bindataVerifyRaw only if its compressed.
+const tmplVerifyDecompressAdaptive = `	if raw.compressed {
+		rc, err := bindataDecompress(r)
+		if err != nil {
+			return fmt.Errorf("asset %s can't read by error: %v", name, err)
+		}
+		defer rc.Close()
+		r = rc
+	}
+`
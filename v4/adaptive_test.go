@@ -0,0 +1,82 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"testing"
+)
+
+func TestHasCompressedMagic(t *testing.T) {
+	tests := []struct {
+		head string
+		exp  bool
+	}{
+		{"\x89PNG\r\n\x1a\n....", true},
+		{"\xff\xd8\xff\xe0", true},
+		{"PK\x03\x04", true},
+		{"wOF2....", true},
+		{"RIFF\x00\x00\x00\x00WEBPVP8 ", true},
+		{"RIFF\x00\x00\x00\x00WAVE", false},
+		{"<!DOCTYPE html>", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		t.Logf("%q", test.head)
+
+		got := hasCompressedMagic([]byte(test.head))
+
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestIsCompressible(t *testing.T) {
+	tests := []struct {
+		name      string
+		minSaving float64
+		exp       bool
+	}{{
+		name: "adaptive/index.html",
+		exp:  true,
+	}, {
+		// The saving is less than the minimum.
+		name:      "adaptive/index.html",
+		minSaving: 0.9,
+	}, {
+		// Skipped by file extension.
+		name: "adaptive/logo.png",
+	}, {
+		// Skipped by magic bytes.
+		name: "adaptive/font.bin",
+	}, {
+		// The compressed data is larger than the original.
+		name: "adaptive/random.dat",
+	}}
+
+	for _, test := range tests {
+		t.Logf("%s with minimum saving %v", test.name, test.minSaving)
+
+		c := &Config{
+			AdaptiveMinSaving:   test.minSaving,
+			AdaptiveCompression: true,
+		}
+		err := c.validateCompression()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ast := &asset{
+			path: "testdata/" + test.name,
+			name: test.name,
+		}
+
+		got, err := isCompressible(c, ast)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert(t, test.exp, got, true)
+	}
+}
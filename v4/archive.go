@@ -0,0 +1,143 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// archiveImports contains list of packages required by the generated
+// functions WriteTar and WriteZip.
+// nolint: gochecknoglobals
+var archiveImports = []string{
+	"archive/tar", "archive/zip", "bytes", "io", "os", "strings",
+}
+
+// writeArchive writes the functions WriteTar and WriteZip in the scope s.
+func writeArchive(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplArchive)
+}
+
+const tmplArchive = `
+//
+// WriteTar writes the assets tree rooted at root into w as tar archive.
+// Each file and directory keep the mode and modification time of the asset,
+// and the file content is streamed using AssetReader.
+// Root "" writes all assets.
+// The w is not closed after the archive is written.
+//
+{{.Decl "WriteTar"}}(w io.Writer, root string) error {
+	tw := tar.NewWriter(w)
+	err := {{.Ref "AssetWalk"}}(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		var (
+			r    io.ReadCloser
+			size int64
+		)
+		if !info.IsDir() {
+			r, info, size, err = {{.Ref "archiveOpen"}}(name)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = strings.TrimPrefix(name, "/")
+		if info.IsDir() {
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		}
+
+		hdr.Size = size
+		err = tw.WriteHeader(hdr)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, r)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+//
+// WriteZip writes the assets tree rooted at root into w as zip archive.
+// Each file and directory keep the mode and modification time of the asset,
+// and the file content is streamed using AssetReader.
+// Root "" writes all assets.
+// The w is not closed after the archive is written.
+//
+{{.Decl "WriteZip"}}(w io.Writer, root string) error {
+	zw := zip.NewWriter(w)
+	err := {{.Ref "AssetWalk"}}(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		var rc io.ReadCloser
+		if !info.IsDir() {
+			rc, info, err = {{.Ref "assetOpen"}}(name)
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+		}
+
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = strings.TrimPrefix(name, "/")
+		if info.IsDir() {
+			hdr.Name += "/"
+			_, err = zw.CreateHeader(hdr)
+			return err
+		}
+
+		hdr.Method = zip.Deflate
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fw, rc)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// {{.Name "archiveOpen"}} return the reader of asset content, its information,
+// and its size.
+// The information is taken along with the opened asset data, so the asset
+// is read only once.
+// If the asset size is not recorded, the content is read into memory to get
+// its size.
+{{.Decl "archiveOpen"}}(name string) (io.ReadCloser, os.FileInfo, int64, error) {
+	rc, info, err := {{.Ref "assetOpen"}}(name)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if info.Size() > 0 {
+		return rc, info, info.Size(), nil
+	}
+	defer rc.Close()
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(rc)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return bindataNopCloser{bytes.NewReader(buf.Bytes())}, info, int64(buf.Len()), nil
+}
+`
@@ -24,6 +24,22 @@ type asset struct {
 	// fi field contains the file information (to minimize calling os.Stat
 	// on the same file while processing).
 	fi os.FileInfo
+
+	// sum contains the hex encoded SHA-256 of asset content, if
+	// HashedNames or SignKey is set.
+	sum string
+
+	// hashedName contains the name of asset with the hash of its content,
+	// if HashedNames is set.
+	hashedName string
+
+	// content contains the asset content whose references has been
+	// rewritten to hashed names, if RewriteHashedNames is set and the
+	// content is changed.
+	content []byte
+
+	// compressed is true if the asset data is compressed in release mode.
+	compressed bool
 }
 
 func normalize(in string) (out string) {
@@ -55,9 +71,9 @@ func newAsset(cfg *Config, path, name, realPath string, fi os.FileInfo) (ast *as
 	}
 
 	if len(realPath) == 0 {
-		ast.funcName = cfg.AssetPrefix + normalize(name)
+		ast.funcName = cfg.AssetPrefix + cfg.Bundle + normalize(name)
 	} else {
-		ast.funcName = cfg.AssetPrefix + normalize(realPath)
+		ast.funcName = cfg.AssetPrefix + cfg.Bundle + normalize(realPath)
 	}
 	return ast
 }
@@ -0,0 +1,454 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeBundle writes the tables of assets as the value of Bundle, followed
+// by the Bundle type and its methods, unless NoBundleType is set, and the
+// package-level functions that call the bundle, if BundleWrapper is set.
+func writeBundle(w io.Writer, c *Config, keys []string, toc map[string]*asset, dirs []*asset) (err error) {
+	err = writeBundleTables(w, c, keys, toc, dirs)
+	if err != nil {
+		return err
+	}
+
+	err = writeBundleState(w, c, keys, toc)
+	if err != nil {
+		return err
+	}
+
+	if !c.NoBundleType {
+		err = writeBundleType(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.BundleWrapper {
+		_, err = fmt.Fprintf(w, tmplBundleWrapper, c.Bundle)
+		if err != nil {
+			return err
+		}
+		if c.withAssetReader() {
+			_, err = fmt.Fprintf(w, tmplBundleWrapperReader, c.Bundle)
+			if err != nil {
+				return err
+			}
+		}
+		err = writeBundleWrapperFeatures(w, c)
+	}
+
+	return err
+}
+
+// writeBundleTables writes the table of assets, the tree of assets, the
+// table of directories, and the table of embedded data of the bundle, and
+// the bundle value that hold them.
+func writeBundleTables(w io.Writer, c *Config, keys []string, toc map[string]*asset, dirs []*asset) (err error) {
+	_, err = fmt.Fprintf(w, tmplBundleFiles, c.Bundle, c.Bundle)
+	if err != nil {
+		return err
+	}
+
+	longestNameLen := getLongestAssetNameLen(keys)
+
+	for _, key := range keys {
+		err = writeTOCAsset(w, toc[key], longestNameLen)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\nvar _bintree%s = &bintree", c.Bundle)
+	if err != nil {
+		return err
+	}
+
+	err = newTOCTree(keys, toc, dirs).writeGoMap(w, 0)
+	if err != nil {
+		return err
+	}
+
+	if c.Debug || c.Dev {
+		_, err = fmt.Fprintf(w, "\nvar _bindataDirs%s = map[string]string{\n",
+			c.Bundle)
+		if err != nil {
+			return err
+		}
+		err = writeDirsDebugEntries(w, c, dirs)
+	} else {
+		_, err = fmt.Fprintf(w, "\nvar _bindataDirs%s = map[string]bindataDirInfo{\n",
+			c.Bundle)
+		if err != nil {
+			return err
+		}
+		err = writeDirsReleaseEntries(w, c, dirs)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !c.Debug && !c.Dev {
+		_, err = fmt.Fprintf(w, "}\n\nvar _bindataRaw%s = map[string]bindataRaw{\n",
+			c.Bundle)
+		if err != nil {
+			return err
+		}
+		err = writeRawEntries(w, c, keys, toc)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, values := bundleFields(c)
+
+	_, err = fmt.Fprintf(w, tmplBundleValue, c.Bundle, c.Bundle,
+		formatBundleFields(values, ":", ","))
+
+	return err
+}
+
+// writeBundleType writes the Bundle type, its methods, and the types used
+// by the methods.
+func writeBundleType(w io.Writer, c *Config) (err error) {
+	types, _ := bundleFields(c)
+
+	_, err = fmt.Fprintf(w, tmplBundleType, formatBundleFields(types, "", ""))
+	if err != nil {
+		return err
+	}
+
+	err = writeBundleAsset(w, c)
+	if err != nil {
+		return err
+	}
+
+	if !c.Debug && !c.Dev {
+		dataType, field := rawFields(c)
+		_, err = fmt.Fprintf(w, tmplRawType, dataType, field)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeRawOpen(w, c, newScope(c))
+	if err != nil {
+		return err
+	}
+
+	err = writeAssetReader(w, c, newScope(c))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(w, tmplBintree, tmplNodeInfoTypes)
+	if err != nil {
+		return err
+	}
+
+	err = writeRestore(w, newScope(c))
+	if err != nil {
+		return err
+	}
+
+	err = writeBundleLayers(w, c)
+	if err != nil {
+		return err
+	}
+
+	if c.FS {
+		err = writeFS(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeBundleFeatures(w, c)
+}
+
+// writeBundleAsset writes the methods of Bundle that read the asset, its
+// information, and the names of assets, with the lookup of hashed name and
+// overlay directory, if its required.
+func writeBundleAsset(w io.Writer, c *Config) (err error) {
+	err = writeScoped(w, newScope(c).withHooks(lookupHooks(c)), tmplFuncAsset)
+	if err != nil {
+		return err
+	}
+
+	err = writeAssetDir(w, c, newScope(c))
+	if err != nil {
+		return err
+	}
+
+	err = writeNodeFuncs(w, c, newScope(c))
+	if err != nil {
+		return err
+	}
+
+	return writeQuery(w, newScope(c))
+}
+
+// writeBundleLayers writes the type BundleLayers, and the method of Bundle
+// that report whether the name exist in the bundle, using the same lookup as
+// the methods Asset and AssetInfo.
+func writeBundleLayers(w io.Writer, c *Config) (err error) {
+	err = writeScoped(w, newScope(c).withHooks(lookupHooks(c)), tmplBundleExist)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, tmplBundleLayers)
+	return err
+}
+
+const tmplBundleFiles = `
+//
+// _bindata%s is a table, holding each asset generator of the bundle,
+// mapped to its name.
+//
+var _bindata%s = map[string]func() (*asset, error){
+`
+
+const tmplBundleValue = `}
+
+//
+// %s contains the assets embedded by go-bindata.
+//
+var %s = &Bundle{
+%s}
+`
+
+const tmplBundleType = `
+//
+// Bundle contains a set of assets embedded by go-bindata.
+// Each bundle is independent from the others, so several bundles can be
+// generated into the same package and passed around as value.
+//
+type Bundle struct {
+%s}
+`
+
+const tmplBundleExist = `
+// exist return true if the asset or directory name can be read from the
+// bundle, by its name in the tree, by its hashed name, or from the overlay
+// directory.
+func (b *Bundle) exist(name string) bool {
+	if b.node(name) != nil {
+		return true
+	}{{.Hook "exist"}}
+	return false
+}
+`
+
+const tmplBundleLayers = `
+//
+// BundleLayers stack several bundles, so the assets in the upper bundle
+// override the assets with the same name in the lower bundles.
+// The first bundle is the top most layer.
+// The asset or directory name is served by the first bundle that contains
+// it, while the content of directory is merged from all bundles.
+//
+type BundleLayers []*Bundle
+
+//
+// Layer returns the bundle that serve the asset or directory name, or nil if
+// the name does not exist in any bundles.
+// The name can be the hashed name of asset, or the file in the overlay
+// directory of bundle.
+//
+func (layers BundleLayers) Layer(name string) *Bundle {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	for _, b := range layers {
+		if b.exist(cannonicalName) {
+			return b
+		}
+	}
+	return nil
+}
+
+//
+// Asset loads and returns the asset for the given name from the top most
+// bundle that contains it.
+//
+func (layers BundleLayers) Asset(name string) ([]byte, error) {
+	b := layers.Layer(name)
+	if b == nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return b.Asset(name)
+}
+
+//
+// MustAsset is like Asset but panics when Asset would return an error.
+//
+func (layers BundleLayers) MustAsset(name string) []byte {
+	a, err := layers.Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+//
+// AssetInfo loads and returns the asset info for the given name from the
+// top most bundle that contains it.
+// The name can be an asset or a directory.
+//
+func (layers BundleLayers) AssetInfo(name string) (os.FileInfo, error) {
+	b := layers.Layer(name)
+	if b == nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return b.AssetInfo(name)
+}
+
+//
+// AssetNames returns the sorted names of the assets in all bundles, where
+// each name is listed once.
+//
+func (layers BundleLayers) AssetNames() []string {
+	uniq := make(map[string]struct{})
+	for _, b := range layers {
+		for name := range b.files {
+			uniq[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(uniq))
+	for name := range uniq {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//
+// AssetDir returns the sorted file names below a certain directory, merged
+// from all bundles that contain the directory.
+// It returns an error if the name is not a directory in the top most bundle
+// that contains it.
+//
+func (layers BundleLayers) AssetDir(name string) ([]string, error) {
+	list, err := layers.AssetDirInfo(name)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list))
+	for _, fi := range list {
+		names = append(names, fi.Name())
+	}
+	return names, nil
+}
+
+//
+// AssetDirInfo returns the file information of files and directories below a
+// certain directory, merged from all bundles, sorted by name.
+// The file information of each child is taken from the top most bundle that
+// contains it.
+//
+func (layers BundleLayers) AssetDirInfo(name string) ([]os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	fi, err := layers.AssetInfo(cannonicalName)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+
+	uniq := make(map[string]struct{})
+	for _, b := range layers {
+		childNames, err := b.AssetDir(cannonicalName)
+		if err != nil {
+			continue
+		}
+		for _, childName := range childNames {
+			uniq[childName] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(uniq))
+	for childName := range uniq {
+		names = append(names, childName)
+	}
+	sort.Strings(names)
+
+	list := make([]os.FileInfo, 0, len(names))
+	for _, childName := range names {
+		fi, err := layers.AssetInfo(path.Join(cannonicalName, childName))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, bindataBaseInfo{FileInfo: fi, name: childName})
+	}
+	return list, nil
+}
+`
+
+const tmplBundleWrapper = `
+// Asset is a shortcut for %[1]s.Asset.
+func Asset(name string) ([]byte, error) {
+	return %[1]s.Asset(name)
+}
+
+// MustAsset is a shortcut for %[1]s.MustAsset.
+func MustAsset(name string) []byte {
+	return %[1]s.MustAsset(name)
+}
+
+// AssetInfo is a shortcut for %[1]s.AssetInfo.
+func AssetInfo(name string) (os.FileInfo, error) {
+	return %[1]s.AssetInfo(name)
+}
+
+// AssetNames is a shortcut for %[1]s.AssetNames.
+func AssetNames() []string {
+	return %[1]s.AssetNames()
+}
+
+// AssetDir is a shortcut for %[1]s.AssetDir.
+func AssetDir(name string) ([]string, error) {
+	return %[1]s.AssetDir(name)
+}
+
+// AssetDirInfo is a shortcut for %[1]s.AssetDirInfo.
+func AssetDirInfo(name string) ([]os.FileInfo, error) {
+	return %[1]s.AssetDirInfo(name)
+}
+
+// AssetWalk is a shortcut for %[1]s.AssetWalk.
+func AssetWalk(root string, fn func(name string, info os.FileInfo, err error) error) error {
+	return %[1]s.AssetWalk(root, fn)
+}
+
+// AssetGlob is a shortcut for %[1]s.AssetGlob.
+func AssetGlob(pattern string) ([]string, error) {
+	return %[1]s.AssetGlob(pattern)
+}
+
+// AssetNamesWithPrefix is a shortcut for %[1]s.AssetNamesWithPrefix.
+func AssetNamesWithPrefix(prefix string) []string {
+	return %[1]s.AssetNamesWithPrefix(prefix)
+}
+
+// RestoreAsset is a shortcut for %[1]s.RestoreAsset.
+func RestoreAsset(dir, name string) error {
+	return %[1]s.RestoreAsset(dir, name)
+}
+
+// RestoreAssets is a shortcut for %[1]s.RestoreAssets.
+func RestoreAssets(dir, name string) error {
+	return %[1]s.RestoreAssets(dir, name)
+}
+`
+
+const tmplBundleWrapperReader = `
+// AssetReader is a shortcut for %[1]s.AssetReader.
+func AssetReader(name string) (io.ReadCloser, error) {
+	return %[1]s.AssetReader(name)
+}
+`
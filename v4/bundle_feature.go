@@ -0,0 +1,386 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// bundleField define the field of Bundle, with its type or its value.
+type bundleField struct {
+	name string
+	expr string
+}
+
+// bundleFields return the fields of Bundle type, and the fields of the
+// bundle value that hold the tables and the state of the bundle.
+func bundleFields(c *Config) (types, values []bundleField) {
+	types = []bundleField{
+		{"files", "map[string]func() (*asset, error)"},
+		{"tree", "*bintree"},
+	}
+	values = []bundleField{
+		{"files", "_bindata" + c.Bundle},
+		{"tree", "_bintree" + c.Bundle},
+		{"dirs", "_bindataDirs" + c.Bundle},
+	}
+	if c.Debug || c.Dev {
+		types = append(types, bundleField{"dirs", "map[string]string"})
+	} else {
+		types = append(types,
+			bundleField{"dirs", "map[string]bindataDirInfo"},
+			bundleField{"raw", "map[string]bindataRaw"},
+		)
+		values = append(values, bundleField{"raw", "_bindataRaw" + c.Bundle})
+	}
+	if c.Cache {
+		types = append(types, bundleField{"cache", "*bindataCache"})
+		values = append(values, bundleField{"cache",
+			fmt.Sprintf("bindataNewCache(_bindata%s, %d)", c.Bundle, c.CacheMaxSize)})
+	}
+	if c.Overlay {
+		types = append(types, bundleField{"overlay", "*bindataOverlay"})
+		values = append(values, bundleField{"overlay",
+			fmt.Sprintf("bindataNewOverlay(%q)", c.OverlayEnv)})
+	}
+	if len(c.EncryptKey) > 0 {
+		types = append(types, bundleField{"key", "*bindataKey"})
+		values = append(values, bundleField{"key", "&_bindataKey" + c.Bundle})
+	}
+	if c.HashedNames {
+		types = append(types,
+			bundleField{"hashed", "map[string]string"},
+			bundleField{"original", "map[string]string"},
+		)
+		values = append(values,
+			bundleField{"hashed", "_bindataHashedNames" + c.Bundle},
+			bundleField{"original", "_bindataOriginalNames" + c.Bundle},
+		)
+	}
+	if c.SignKey != nil {
+		types = append(types,
+			bundleField{"manifest", "string"},
+			bundleField{"signature", "string"},
+		)
+		values = append(values,
+			bundleField{"manifest", "_bindataManifest" + c.Bundle},
+			bundleField{"signature", "_bindataSignature" + c.Bundle},
+		)
+		if c.Debug || c.Dev {
+			types = append(types, bundleField{"signTOC",
+				"map[string]func() (*asset, error)"})
+		}
+	}
+	return types, values
+}
+
+// formatBundleFields return the fields as the lines of struct type or
+// composite literal, aligned by their name, where each name is followed by
+// sep and each line is ended by end.
+func formatBundleFields(fields []bundleField, sep, end string) string {
+	var longest int
+	for _, f := range fields {
+		if len(f.name) > longest {
+			longest = len(f.name)
+		}
+	}
+
+	var sb strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&sb, "\t%-*s %s%s\n", longest+len(sep), f.name+sep,
+			f.expr, end)
+	}
+	return sb.String()
+}
+
+// writeBundleState writes the state of bundle that is not shared with the
+// other bundles: the key of encrypted assets, the tables of hashed names,
+// the signed manifest, and the init function that wrap the asset functions.
+func writeBundleState(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	if len(c.EncryptKey) > 0 {
+		_, err = fmt.Fprintf(w, tmplBundleKeyVar, c.Bundle, c.Bundle, c.Bundle)
+		if err != nil {
+			return err
+		}
+	}
+	if c.HashedNames {
+		err = writeHashedNamesTables(w, c.Bundle, keys, toc)
+		if err != nil {
+			return err
+		}
+	}
+	if c.SignKey != nil {
+		err = writeSignManifest(w, c, c.Bundle, keys, toc)
+		if err != nil {
+			return err
+		}
+	}
+	if c.withWrap() {
+		_, err = fmt.Fprintf(w, tmplBundleInit, c.Bundle)
+	}
+	return err
+}
+
+// writeBundleFeatures writes the methods of Bundle for the optional parts of
+// the generated API, in the same order as writeFeatures.
+func writeBundleFeatures(w io.Writer, c *Config) (err error) {
+	if c.HTTPFileSystem {
+		err = writeHTTPFileSystem(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.HTTPHandler {
+		err = writeHandler(w, c, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.Archive {
+		err = writeArchive(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.RestoreWithOptions {
+		err = writeRestoreWithOptions(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.Verify {
+		err = writeVerify(w, c, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.Cache {
+		err = writeCache(w, c, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.Overlay {
+		err = writeOverlay(w, c, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.ContentType {
+		err = writeContentType(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.withMeta() {
+		err = writeMeta(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.HashedNames {
+		err = writeHashedNames(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if c.SignKey != nil {
+		err = writeVerifySignature(w, c, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.EncryptKey) > 0 {
+		err = writeEncrypt(w, newScope(c))
+		if err != nil {
+			return err
+		}
+	}
+	if (c.Debug || c.Dev) && (c.ContentType || c.withMeta()) {
+		err = writeDebugInfo(w, c)
+		if err != nil {
+			return err
+		}
+	}
+	return writeWrap(w, c, newScope(c))
+}
+
+// writeBundleWrapperFeatures writes the package-level functions that call
+// the methods of bundle for the optional parts of the generated API.
+func writeBundleWrapperFeatures(w io.Writer, c *Config) (err error) {
+	var tmpls []string
+
+	if c.HTTPFileSystem {
+		tmpls = append(tmpls, tmplBundleWrapperHTTPFileSystem)
+	}
+	if c.HTTPHandler {
+		tmpls = append(tmpls, tmplBundleWrapperHandler)
+	}
+	if c.Archive {
+		tmpls = append(tmpls, tmplBundleWrapperArchive)
+	}
+	if c.RestoreWithOptions {
+		tmpls = append(tmpls, tmplBundleWrapperRestoreWithOptions)
+	}
+	if c.Verify {
+		tmpls = append(tmpls, tmplBundleWrapperVerify)
+	}
+	if c.Cache {
+		tmpls = append(tmpls, tmplBundleWrapperCache)
+	}
+	if c.Overlay {
+		tmpls = append(tmpls, tmplBundleWrapperOverlay)
+	}
+	if c.ContentType {
+		tmpls = append(tmpls, tmplBundleWrapperContentType)
+	}
+	if c.withMeta() {
+		tmpls = append(tmpls, tmplBundleWrapperMeta)
+	}
+	if c.HashedNames {
+		tmpls = append(tmpls, tmplBundleWrapperHashedNames)
+	}
+	if c.SignKey != nil {
+		tmpls = append(tmpls, tmplBundleWrapperSignature)
+	}
+	if len(c.EncryptKey) > 0 {
+		tmpls = append(tmpls, tmplBundleWrapperEncrypt)
+	}
+
+	for _, tmpl := range tmpls {
+		_, err = fmt.Fprintf(w, tmpl, c.Bundle)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const tmplBundleKeyVar = `
+// _bindataKey%s contains the key that decrypt the assets of %s.
+// nolint: gochecknoglobals
+var _bindataKey%s bindataKey
+`
+
+const tmplBundleInit = `
+func init() {
+	%s.wrap()
+}
+`
+
+const tmplBundleWrapperHTTPFileSystem = `
+// AssetHTTPFileSystem is a shortcut for %[1]s.AssetHTTPFileSystem.
+func AssetHTTPFileSystem() http.FileSystem {
+	return %[1]s.AssetHTTPFileSystem()
+}
+`
+
+const tmplBundleWrapperHandler = `
+// AssetHandler is a shortcut for %[1]s.AssetHandler.
+func AssetHandler() http.Handler {
+	return %[1]s.AssetHandler()
+}
+`
+
+const tmplBundleWrapperArchive = `
+// WriteTar is a shortcut for %[1]s.WriteTar.
+func WriteTar(w io.Writer, root string) error {
+	return %[1]s.WriteTar(w, root)
+}
+
+// WriteZip is a shortcut for %[1]s.WriteZip.
+func WriteZip(w io.Writer, root string) error {
+	return %[1]s.WriteZip(w, root)
+}
+`
+
+const tmplBundleWrapperRestoreWithOptions = `
+// RestoreAssetsWithOptions is a shortcut for %[1]s.RestoreAssetsWithOptions.
+func RestoreAssetsWithOptions(ctx context.Context, dir, name string, opts RestoreOptions) (RestoreSummary, error) {
+	return %[1]s.RestoreAssetsWithOptions(ctx, dir, name, opts)
+}
+`
+
+const tmplBundleWrapperVerify = `
+// VerifyAssets is a shortcut for %[1]s.VerifyAssets.
+func VerifyAssets(ctx context.Context) []error {
+	return %[1]s.VerifyAssets(ctx)
+}
+`
+
+const tmplBundleWrapperCache = `
+// AssetCache is a shortcut for %[1]s.AssetCache().
+// nolint: gochecknoglobals
+var AssetCache = %[1]s.AssetCache()
+`
+
+const tmplBundleWrapperOverlay = `
+// SetOverlayDir is a shortcut for %[1]s.SetOverlayDir.
+func SetOverlayDir(dir string) {
+	%[1]s.SetOverlayDir(dir)
+}
+
+// OverlayDir is a shortcut for %[1]s.OverlayDir.
+func OverlayDir() string {
+	return %[1]s.OverlayDir()
+}
+`
+
+const tmplBundleWrapperContentType = `
+// AssetContentType is a shortcut for %[1]s.AssetContentType.
+func AssetContentType(name string) (string, error) {
+	return %[1]s.AssetContentType(name)
+}
+
+// AssetDataURI is a shortcut for %[1]s.AssetDataURI.
+func AssetDataURI(name string) (string, error) {
+	return %[1]s.AssetDataURI(name)
+}
+`
+
+const tmplBundleWrapperMeta = `
+// AssetMeta is a shortcut for %[1]s.AssetMeta.
+func AssetMeta(name string) (map[string]string, error) {
+	return %[1]s.AssetMeta(name)
+}
+`
+
+const tmplBundleWrapperHashedNames = `
+// AssetHashedName is a shortcut for %[1]s.AssetHashedName.
+func AssetHashedName(name string) (string, error) {
+	return %[1]s.AssetHashedName(name)
+}
+
+// AssetOriginalName is a shortcut for %[1]s.AssetOriginalName.
+func AssetOriginalName(hashed string) (string, error) {
+	return %[1]s.AssetOriginalName(hashed)
+}
+`
+
+const tmplBundleWrapperSignature = `
+// VerifySignature is a shortcut for %[1]s.VerifySignature.
+func VerifySignature(pub ed25519.PublicKey) error {
+	return %[1]s.VerifySignature(pub)
+}
+
+// VerifySignatureDir is a shortcut for %[1]s.VerifySignatureDir.
+func VerifySignatureDir(pub ed25519.PublicKey, dir string) error {
+	return %[1]s.VerifySignatureDir(pub, dir)
+}
+`
+
+const tmplBundleWrapperEncrypt = `
+// SetAssetKey is a shortcut for %[1]s.SetAssetKey.
+func SetAssetKey(key []byte) error {
+	return %[1]s.SetAssetKey(key)
+}
+
+// SetAssetKeyProvider is a shortcut for %[1]s.SetAssetKeyProvider.
+func SetAssetKeyProvider(provider func() ([]byte, error)) {
+	%[1]s.SetAssetKeyProvider(provider)
+}
+`
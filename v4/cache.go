@@ -0,0 +1,239 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// cacheImports contains list of packages required by the generated asset
+// cache.
+// nolint: gochecknoglobals
+var cacheImports = []string{
+	"container/list", "context", "runtime", "sync",
+}
+
+// writeCache writes the asset cache, and the function AssetCache in the
+// scope s.
+// In debug mode, the cache is not installed, so the assets are always read
+// from disk, but the cache API is still provided.
+func writeCache(w io.Writer, c *Config, s genScope) (err error) {
+	if s.Bundle() {
+		_, err = io.WriteString(w, tmplBundleCache)
+	} else {
+		_, err = fmt.Fprintf(w, tmplCache, c.CacheMaxSize)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, tmplCacheType)
+	return err
+}
+
+const tmplCache = `
+//
+// AssetCache memoize the content of assets, so each asset is decompressed
+// only once, even if its requested by concurrent callers.
+// The total size of cached content is limited by its maximum size, where the
+// least recently used assets are evicted first.
+// The asset content returned from cache is shared by all callers, it should
+// not be modified.
+//
+var AssetCache = bindataNewCache(_bindata, %d)
+`
+
+// tmplCacheType contains the type of cache and its methods, where Preload
+// load the assets in the table of assets.
+const tmplCacheType = `
+type bindataCacheEntry struct {
+	once sync.Once
+	name string
+	a    *asset
+	err  error
+	size int64
+	elem *list.Element
+}
+
+type bindataCache struct {
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+	entries map[string]*bindataCacheEntry
+	lru     *list.List
+	files   map[string]func() (*asset, error)
+}
+
+// bindataNewCache create the cache for the assets in files, with the given
+// maximum size.
+func bindataNewCache(files map[string]func() (*asset, error), maxSize int64) *bindataCache {
+	return &bindataCache{
+		maxSize: maxSize,
+		entries: make(map[string]*bindataCacheEntry),
+		lru:     list.New(),
+		files:   files,
+	}
+}
+
+// get return the cached asset name, or load it using f.
+func (c *bindataCache) get(name string, f func() (*asset, error)) (*asset, error) {
+	c.mu.Lock()
+	e, ok := c.entries[name]
+	if !ok {
+		e = &bindataCacheEntry{name: name}
+		c.entries[name] = e
+	} else if e.elem != nil {
+		c.lru.MoveToFront(e.elem)
+	}
+	c.mu.Unlock()
+
+	e.once.Do(func() {
+		e.a, e.err = f()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.entries[name] != e {
+			// The cache has been purged while loading.
+			return
+		}
+		if e.err != nil {
+			delete(c.entries, name)
+			return
+		}
+		e.size = int64(len(e.a.bytes))
+		if c.maxSize > 0 && e.size > c.maxSize {
+			delete(c.entries, name)
+			return
+		}
+		e.elem = c.lru.PushFront(e)
+		c.size += e.size
+		c.evict()
+	})
+
+	return e.a, e.err
+}
+
+// evict remove the least recently used assets until the cache size is
+// below its maximum size.
+// The caller must hold the lock.
+func (c *bindataCache) evict() {
+	for c.maxSize > 0 && c.size > c.maxSize {
+		back := c.lru.Back()
+		if back == nil {
+			return
+		}
+		e := back.Value.(*bindataCacheEntry)
+		c.lru.Remove(back)
+		delete(c.entries, e.name)
+		c.size -= e.size
+	}
+}
+
+// wrap return the function that load the asset name from cache.
+func (c *bindataCache) wrap(name string, f func() (*asset, error)) func() (*asset, error) {
+	return func() (*asset, error) {
+		return c.get(name, f)
+	}
+}
+
+// MaxSize return the maximum size of cache in bytes.
+// Zero means the cache size is not limited.
+func (c *bindataCache) MaxSize() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxSize
+}
+
+// SetMaxSize change the maximum size of cache in bytes, evicting the least
+// recently used assets if necessary.
+// Zero means the cache size is not limited.
+func (c *bindataCache) SetMaxSize(maxSize int64) {
+	c.mu.Lock()
+	c.maxSize = maxSize
+	c.evict()
+	c.mu.Unlock()
+}
+
+// Size return the total size of cached assets in bytes.
+func (c *bindataCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Purge remove all assets from cache.
+func (c *bindataCache) Purge() {
+	c.mu.Lock()
+	c.entries = make(map[string]*bindataCacheEntry)
+	c.lru.Init()
+	c.size = 0
+	c.mu.Unlock()
+}
+
+// Preload load all assets into cache concurrently.
+// It returns the first error when loading the asset, or the context error if
+// ctx is done before all assets are loaded.
+func (c *bindataCache) Preload(ctx context.Context) (err error) {
+	var (
+		names = make(chan string)
+		errc  = make(chan error, 1)
+		wg    sync.WaitGroup
+	)
+
+	for x := 0; x < runtime.GOMAXPROCS(0); x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				_, err := c.files[name]()
+				if err != nil {
+					select {
+					case errc <- err:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+loop:
+	for name := range c.files {
+		select {
+		case names <- name:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		case err = <-errc:
+			break loop
+		}
+	}
+	close(names)
+	wg.Wait()
+
+	if err == nil {
+		select {
+		case err = <-errc:
+		default:
+		}
+	}
+	return err
+}
+`
+
+const tmplBundleCache = `
+//
+// AssetCache return the cache that memoize the content of assets in the
+// bundle, so each asset is decompressed only once, even if its requested by
+// concurrent callers.
+// The total size of cached content is limited by its maximum size, where the
+// least recently used assets are evicted first.
+// The asset content returned from cache is shared by all callers, it should
+// not be modified.
+//
+func (b *Bundle) AssetCache() *bindataCache {
+	return b.cache
+}
+`
@@ -0,0 +1,257 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"crypto/md5" //nolint: gas
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strings"
+)
+
+// checksumAlgo define the hash algorithm that can be set in Checksums.
+type checksumAlgo struct {
+	new func() hash.Hash
+
+	// expr is the expression that create the hash in the generated code.
+	expr string
+
+	// pkg is the package that is imported by the generated code to
+	// create the hash.
+	pkg string
+}
+
+// checksumAlgos contains the supported hash algorithms, mapped by its name.
+// nolint: gochecknoglobals
+var checksumAlgos = map[string]checksumAlgo{
+	"crc32":  {new: func() hash.Hash { return crc32.NewIEEE() }, expr: "crc32.NewIEEE()", pkg: "hash/crc32"},
+	"md5":    {new: md5.New, expr: "md5.New()", pkg: "crypto/md5"},
+	"sha1":   {new: sha1.New, expr: "sha1.New()", pkg: "crypto/sha1"},
+	"sha256": {new: sha256.New, expr: "sha256.New()", pkg: "crypto/sha256"},
+	"sha384": {new: sha512.New384, expr: "sha512.New384()", pkg: "crypto/sha512"},
+	"sha512": {new: sha512.New, expr: "sha512.New()", pkg: "crypto/sha512"},
+}
+
+// checksumNames return the sorted and unique names of hash algorithms in
+// Checksums, excluding md5 which is stored separately.
+func (c *Config) checksumNames() (names []string) {
+	seen := make(map[string]bool, len(c.Checksums))
+	for _, name := range c.Checksums {
+		name = strings.ToLower(name)
+		if name == "md5" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateChecksums check the name of hash algorithms in Checksums.
+// If md5 is in the list, MD5Checksum is set to true.
+func (c *Config) validateChecksums() error {
+	for _, name := range c.Checksums {
+		name = strings.ToLower(name)
+		if _, ok := checksumAlgos[name]; !ok {
+			return fmt.Errorf("%w %q", ErrChecksumAlgo, name)
+		}
+		if name == "md5" {
+			c.MD5Checksum = true
+		}
+	}
+	return nil
+}
+
+// overlayChecksumImports return the packages required by the generated
+// methods Checksum and Integrity of overlay asset.
+func overlayChecksumImports(c *Config) (pkgs []string) {
+	names := c.checksumNames()
+	if len(names) > 0 {
+		pkgs = append(pkgs, "fmt")
+	}
+	for _, name := range names {
+		pkgs = append(pkgs, checksumAlgos[name].pkg)
+	}
+	if c.Integrity {
+		pkgs = append(pkgs, "crypto/sha512", "encoding/base64")
+	}
+	return pkgs
+}
+
+// assetHashes compute the checksums of asset content while its being read
+// for compression, so the asset file is read only once.
+type assetHashes struct {
+	md5       hash.Hash
+	sri       hash.Hash
+	names     []string
+	checksums []hash.Hash
+}
+
+func newAssetHashes(c *Config) (ah *assetHashes) {
+	ah = &assetHashes{
+		names: c.checksumNames(),
+	}
+	if c.MD5Checksum {
+		ah.md5 = md5.New()
+	}
+	if c.Integrity {
+		ah.sri = sha512.New384()
+	}
+	for _, name := range ah.names {
+		ah.checksums = append(ah.checksums, checksumAlgos[name].new())
+	}
+	return ah
+}
+
+// wrap return the reader that write the content of r into all hashes while
+// its being read.
+func (ah *assetHashes) wrap(r io.Reader) io.Reader {
+	var writers []io.Writer
+
+	if ah.md5 != nil {
+		writers = append(writers, ah.md5)
+	}
+	if ah.sri != nil {
+		writers = append(writers, ah.sri)
+	}
+	for _, h := range ah.checksums {
+		writers = append(writers, h)
+	}
+	if len(writers) == 0 {
+		return r
+	}
+	return io.TeeReader(r, io.MultiWriter(writers...))
+}
+
+// md5sum return the hex encoded MD5 checksum, or empty string if its not
+// computed.
+func (ah *assetHashes) md5sum() string {
+	if ah.md5 == nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", ah.md5.Sum(nil))
+}
+
+// fields return the fields of bindataFileInfo that contains the checksums
+// and the integrity.
+func (ah *assetHashes) fields() string {
+	var sb strings.Builder
+
+	if len(ah.checksums) > 0 {
+		sb.WriteString("\n\tchecksums: map[string]string{\n")
+		for x, h := range ah.checksums {
+			fmt.Fprintf(&sb, "\t\t%q: \"%x\",\n", ah.names[x], h.Sum(nil))
+		}
+		sb.WriteString("\t},")
+	}
+	if ah.sri != nil {
+		fmt.Fprintf(&sb, "\n\tintegrity: \"sha384-%s\",",
+			base64.StdEncoding.EncodeToString(ah.sri.Sum(nil)))
+	}
+
+	return sb.String()
+}
+
+// writeOverlayChecksum writes the methods Checksum and Integrity of the
+// asset in the overlay directory, which compute them on the fly.
+func writeOverlayChecksum(w io.Writer, c *Config) (err error) {
+	names := c.checksumNames()
+
+	if len(c.Checksums) > 0 && len(names) == 0 {
+		_, err = io.WriteString(w, tmplOverlayChecksumMD5)
+		if err != nil {
+			return err
+		}
+	}
+	if len(names) > 0 {
+		_, err = io.WriteString(w, tmplOverlayChecksum)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			_, err = fmt.Fprintf(w, "\tcase %q:\n\t\th = %s\n", name,
+				checksumAlgos[name].expr)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, tmplOverlayChecksumEnd)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Integrity {
+		_, err = io.WriteString(w, tmplOverlayIntegrity)
+	}
+	return err
+}
+
+const tmplFileInfoChecksum = `
+// Checksum return the hex encoded checksum of asset content using the hash
+// algorithm, or empty string if its not computed.
+func (fi bindataFileInfo) Checksum(algo string) string {
+	if algo == "md5" {
+		return fi.md5checksum
+	}
+	return fi.checksums[algo]
+}
+`
+
+const tmplFileInfoIntegrity = `
+// Integrity return the Subresource Integrity of asset content, using the
+// SHA-384 hash, that can be used in the integrity attribute of HTML script
+// and link elements.
+func (fi bindataFileInfo) Integrity() string {
+	return fi.integrity
+}
+`
+
+const tmplOverlayChecksumMD5 = `
+// Checksum return the hex encoded checksum of asset content in the overlay
+// directory, using the hash algorithm.
+func (fi bindataOverlayInfo) Checksum(algo string) string {
+	if algo == "md5" {
+		return fi.MD5Checksum()
+	}
+	return ""
+}
+`
+
+const tmplOverlayChecksum = `
+// Checksum return the hex encoded checksum of asset content in the overlay
+// directory, using the hash algorithm.
+func (fi bindataOverlayInfo) Checksum(algo string) string {
+	var h hash.Hash
+	switch algo {
+	case "md5":
+		return fi.MD5Checksum()
+`
+
+const tmplOverlayChecksumEnd = `	default:
+		return ""
+	}
+	return fmt.Sprintf("%x", fi.sum(h))
+}
+`
+
+const tmplOverlayIntegrity = `
+// Integrity return the Subresource Integrity of asset content in the overlay
+// directory.
+func (fi bindataOverlayInfo) Integrity() string {
+	sum := fi.sum(sha512.New384())
+	if sum == nil {
+		return ""
+	}
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum)
+}
+`
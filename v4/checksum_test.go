@@ -0,0 +1,53 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestValidateChecksums(t *testing.T) {
+	cfg := &Config{
+		Checksums: []string{"SHA256", "md5"},
+	}
+
+	err := cfg.validateChecksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, true, cfg.MD5Checksum, true)
+	assert(t, []string{"sha256"}, cfg.checksumNames(), true)
+
+	cfg.Checksums = []string{"sha3"}
+
+	err = cfg.validateChecksums()
+	assert(t, `unknown checksum algorithm "sha3"`, err.Error(), true)
+}
+
+func TestAssetHashes(t *testing.T) {
+	cfg := &Config{
+		MD5Checksum: true,
+		Checksums:   []string{"sha1", "crc32"},
+		Integrity:   true,
+	}
+
+	hashes := newAssetHashes(cfg)
+
+	_, err := ioutil.ReadAll(hashes.wrap(strings.NewReader("abc")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "\n\tchecksums: map[string]string{\n" +
+		"\t\t\"crc32\": \"352441c2\",\n" +
+		"\t\t\"sha1\": \"a9993e364706816aba3e25717850c26c9cd0d89d\",\n" +
+		"\t}," +
+		"\n\tintegrity: \"sha384-ywB1P0WjXou1oD1pmsZQBycsMqsO3tFjGotgWkP/W+2AhgcroefMI1i67KE0yCWn\","
+
+	assert(t, "900150983cd24fb0d6963f7d28e17f72", hashes.md5sum(), true)
+	assert(t, exp, hashes.fields(), true)
+}
@@ -7,11 +7,14 @@ package main
 import (
 	"errors"
 	"flag"
+	"io/ioutil"
 	"log"
+	"mime"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 
 	"github.com/shuLhan/go-bindata/v4"
 )
@@ -38,22 +41,37 @@ var (
 
 // List of error messages.
 var (
+	ErrInvalidContentTypes = errors.New("Invalid -contenttypes value, expecting <regex pattern>=<content type>")
 	ErrInvalidIgnoreRegex  = errors.New("Invalid -ignore regex pattern")
 	ErrInvalidIncludeRegex = errors.New("Invalid -include regex pattern")
+	ErrInvalidMeta         = errors.New("Invalid -meta value, expecting <regex pattern>=<key>=<value>")
 	ErrInvalidPrefixRegex  = errors.New("Invalid -prefix regex pattern")
 	ErrNoInput             = errors.New("Missing <input directories>")
 )
 
 // List of local variables.
 var (
-	argIgnore  []string
-	argInclude []string
-	argVersion bool
-	argPrefix  string
-	cfg        *bindata.Config
+	argContentTypes []string
+	argEncryptKey   string
+	argIgnore       []string
+	argInclude      []string
+	argMeta         []string
+	argVersion      bool
+	argPrefix       string
+	argSignKey      string
+	cfg             *bindata.Config
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == cmdVet {
+		runVet(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == cmdSign {
+		runSign(os.Args[2:])
+		return
+	}
+
 	initArgs()
 
 	err := parseArgs()
@@ -77,6 +95,8 @@ func main() {
 
 func usage() {
 	lerr.Println("Usage: " + appName + " [options] <input directories>")
+	lerr.Println("       " + appName + " " + cmdVet + " [options] [package directories]")
+	lerr.Println("       " + appName + " " + cmdSign + " -key <private key> <directories>")
 
 	flag.PrintDefaults()
 }
@@ -97,27 +117,63 @@ func version() {
 // initArgs will initialize all command line arguments.
 func initArgs() {
 	cfg = bindata.NewConfig()
+	argContentTypes = nil
+	argIgnore = nil
+	argInclude = nil
+	argMeta = nil
 
 	flag.Usage = usage
 
 	flag.BoolVar(&argVersion, "version", false, "Displays version information.")
+	flag.BoolVar(&cfg.AdaptiveCompression, "adaptivecompression", cfg.AdaptiveCompression, "Decide the compression per asset, skipping the assets that are already compressed or does not compress well.")
+	flag.BoolVar(&cfg.Archive, "archive", cfg.Archive, "Generate WriteTar and WriteZip functions that write the assets as tar or zip archive.")
+	flag.BoolVar(&cfg.AssetReader, "assetreader", cfg.AssetReader, "Generate AssetReader function that return the streaming reader of asset content.")
+	flag.BoolVar(&cfg.BundleWrapper, "bundlewrapper", cfg.BundleWrapper, "Generate package-level functions that call the methods of bundle.")
+	flag.BoolVar(&cfg.Cache, "cache", cfg.Cache, "Generate AssetCache that memoize the decompressed assets.")
+	flag.BoolVar(&cfg.ContentType, "contenttype", cfg.ContentType, "Detect the MIME type of assets and generate AssetContentType and AssetDataURI functions.")
 	flag.BoolVar(&cfg.Debug, "debug", cfg.Debug, "Do not embed the assets, but provide the embedding API. Contents will still be loaded from disk.")
 	flag.BoolVar(&cfg.Dev, "dev", cfg.Dev, "Similar to debug, but does not emit absolute paths. Expects a rootDir variable to already exist in the generated code's package.")
+	flag.BoolVar(&cfg.EmptyDirs, "emptydirs", cfg.EmptyDirs, "Keep the directories that does not contain any asset.")
+	flag.BoolVar(&cfg.FS, "fs", cfg.FS, "Generate AssetFS function that return the assets as fs.FS (require Go 1.16 or later).")
+	flag.BoolVar(&cfg.HashedNames, "hashednames", cfg.HashedNames, "Register each asset under its name with the hash of its content too, and generate AssetHashedName and AssetOriginalName functions.")
+	flag.BoolVar(&cfg.HTTPFileSystem, "httpfs", cfg.HTTPFileSystem, "Generate AssetHTTPFileSystem function that return the assets as http.FileSystem.")
+	flag.BoolVar(&cfg.HTTPHandler, "httphandler", cfg.HTTPHandler, "Generate AssetHandler function that return an http.Handler to serve the assets.")
+	flag.BoolVar(&cfg.Integrity, "integrity", cfg.Integrity, "Subresource Integrity (SHA-384) will be calculated for assets.")
 	flag.BoolVar(&cfg.MD5Checksum, "md5checksum", cfg.MD5Checksum, "MD5 checksums will be calculated for assets.")
+	flag.BoolVar(&cfg.MetaFiles, "metafiles", cfg.MetaFiles, "Read the metadata of asset from sidecar file <asset>.meta.json, which is not embedded.")
+	flag.BoolVar(&cfg.NameConsts, "nameconsts", cfg.NameConsts, "Generate the constant for each asset name.")
+	flag.BoolVar(&cfg.NoBundleType, "nobundletype", cfg.NoBundleType, "Do not generate the Bundle type, when another bundle in the package already has it.")
 	flag.BoolVar(&cfg.NoCompress, "nocompress", cfg.NoCompress, "Assets will *not* be GZIP compressed when this flag is specified.")
 	flag.BoolVar(&cfg.NoMemCopy, "nomemcopy", cfg.NoMemCopy, "Use a .rodata hack to get rid of unnecessary memcopies. Refer to the documentation to see what implications this carries.")
 	flag.BoolVar(&cfg.NoMetadata, "nometadata", cfg.NoMetadata, "Assets will not preserve size, mode, and modtime info.")
+	flag.BoolVar(&cfg.Overlay, "overlay", cfg.Overlay, "Generate SetOverlayDir function to override the assets with files from directory.")
+	flag.BoolVar(&cfg.RestoreWithOptions, "restorewithoptions", cfg.RestoreWithOptions, "Generate RestoreAssetsWithOptions function to restore the assets with options.")
+	flag.BoolVar(&cfg.RewriteHashedNames, "rewritehashednames", cfg.RewriteHashedNames, "Rewrite the references to other assets inside CSS and HTML assets to their hashed names.")
 	flag.BoolVar(&cfg.Split, "split", cfg.Split, "Split output into several files, avoiding to have a big output file.")
-	flag.BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Print the name of file as they are being processed.")
+	flag.BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Print the name of file as they are being processed, and the warnings.")
+	flag.BoolVar(&cfg.Verify, "verify", cfg.Verify, "Generate VerifyAssets function that verify the embedded assets against their MD5 checksum and size.")
+	flag.BoolVar(&cfg.VerifyOnLoad, "verifyonload", cfg.VerifyOnLoad, "Verify each asset against its MD5 checksum and size every time its loaded.")
+	flag.Float64Var(&cfg.AdaptiveMinSaving, "adaptiveminsaving", cfg.AdaptiveMinSaving, "Optional minimum fraction of asset size, between 0 and 1, that must be saved by compression, otherwise the asset is stored uncompressed (default 0.1).")
+	flag.Int64Var(&cfg.CacheMaxSize, "cachemaxsize", cfg.CacheMaxSize, "Optional maximum size of AssetCache in bytes.")
 	flag.Int64Var(&cfg.ModTime, "modtime", cfg.ModTime, "Optional modification unix timestamp override for all files.")
+	flag.IntVar(&cfg.CompressionLevel, "compressionlevel", cfg.CompressionLevel, "Optional compression level, from 1 (best speed) to 9 (best compression), or -2 for Huffman-only.")
+	flag.StringVar(&cfg.Bundle, "bundle", cfg.Bundle, "Optional name of variable that hold the assets as *Bundle, whose methods provide the API.")
+	flag.StringVar(&cfg.Compression, "compression", cfg.Compression, "Optional compression of assets: gzip (default), zlib, flate, flatedict (flate with the preset dictionary built from the assets), or none.")
+	flag.StringVar(&argEncryptKey, "encryptkey", "", "Optional file of hex encoded AES-256 key that encrypt the assets, which must be set at runtime by SetAssetKey or SetAssetKeyProvider.")
+	flag.StringVar(&cfg.HashedNamesManifest, "hashednamesmanifest", cfg.HashedNamesManifest, "Optional path of JSON file that map the original name of each asset to its hashed name.")
+	flag.StringVar(&cfg.OverlayEnv, "overlayenv", cfg.OverlayEnv, "Optional name of environment variable that contains the overlay directory.")
 	flag.StringVar(&argPrefix, "prefix", "", "Optional path prefix to strip off asset names.")
+	flag.StringVar(&argSignKey, "signkey", "", "Optional PEM file of ed25519 private key that sign the manifest of assets, and generate VerifySignature and VerifySignatureDir functions.")
 	flag.StringVar(&cfg.Output, "o", cfg.Output, "Optional name of the output file to be generated.")
 	flag.StringVar(&cfg.Package, "pkg", cfg.Package, "Package name to use in the generated code.")
 	flag.StringVar(&cfg.Tags, "tags", cfg.Tags, "Optional set of build tags to include.")
 	flag.StringVar(&cfg.AssetPrefix, "assetprefix", cfg.AssetPrefix, "Prefix for the name of the asset function. Begin with a capital letter to export them")
 	flag.UintVar(&cfg.Mode, "mode", cfg.Mode, "Optional file mode override for all files.")
+	flag.Var((*AppendSliceValue)(&cfg.Checksums), "checksum", "Hash algorithm (crc32, md5, sha1, sha256, sha384, or sha512) whose checksum will be calculated for assets, can be set multiple times.")
+	flag.Var((*AppendSliceValue)(&argContentTypes), "contenttypes", "Override the MIME type of assets whose path match the regex pattern, in the format \"<regex pattern>=<content type>\". The pattern can not contains \"=\", use \"\\x3d\" to match it.")
 	flag.Var((*AppendSliceValue)(&argIgnore), "ignore", "Regex pattern to ignore")
 	flag.Var((*AppendSliceValue)(&argInclude), "include", "Regex pattern to include")
+	flag.Var((*AppendSliceValue)(&argMeta), "meta", "Set the metadata of assets whose path match the regex pattern, in the format \"<regex pattern>=<key>=<value>\". The pattern and key can not contains \"=\", use \"\\x3d\" in the pattern to match it.")
 }
 
 // parseArgs creates a new, filled configuration instance by reading and parsing
@@ -158,6 +214,26 @@ func parseArgs() (err error) {
 		return
 	}
 
+	err = parseContentTypes()
+	if err != nil {
+		return
+	}
+
+	err = parseMeta()
+	if err != nil {
+		return
+	}
+
+	err = parseSignKey()
+	if err != nil {
+		return
+	}
+
+	err = parseEncryptKey()
+	if err != nil {
+		return
+	}
+
 	parseOutputPkg()
 
 	// Create input configurations.
@@ -212,6 +288,86 @@ func parseInclude() (err error) {
 	return
 }
 
+func parseContentTypes() (err error) {
+	for _, arg := range argContentTypes {
+		// The value is split at the first "=", since the content type
+		// may contain "=" in its parameters, for example
+		// "charset=utf-8".
+		// The pattern that contains "=" is rejected by validating the
+		// content type.
+		x := strings.Index(arg, "=")
+		if x <= 0 || x == len(arg)-1 {
+			return ErrInvalidContentTypes
+		}
+		_, _, err = mime.ParseMediaType(arg[x+1:])
+		if err != nil {
+			return ErrInvalidContentTypes
+		}
+
+		pattern, err := regexp.Compile(arg[:x])
+		if err != nil {
+			return ErrInvalidContentTypes
+		}
+
+		cfg.ContentTypes = append(cfg.ContentTypes, bindata.ContentTypeConfig{
+			Pattern:     pattern,
+			ContentType: arg[x+1:],
+		})
+	}
+
+	return
+}
+
+func parseMeta() (err error) {
+	for _, arg := range argMeta {
+		// The value is split at the first and second "=", since the
+		// metadata value may contain "=", for example
+		// "max-age=3600", so the pattern and the key can not contain
+		// "=".
+		fields := strings.SplitN(arg, "=", 3)
+		if len(fields) != 3 || len(fields[0]) == 0 || len(fields[1]) == 0 {
+			return ErrInvalidMeta
+		}
+
+		pattern, err := regexp.Compile(fields[0])
+		if err != nil {
+			return ErrInvalidMeta
+		}
+
+		cfg.Meta = append(cfg.Meta, bindata.MetaConfig{
+			Pattern: pattern,
+			Meta:    map[string]string{fields[1]: fields[2]},
+		})
+	}
+
+	return
+}
+
+func parseSignKey() (err error) {
+	if len(argSignKey) == 0 {
+		return nil
+	}
+
+	cfg.SignKey, err = readSignKey(argSignKey)
+
+	return err
+}
+
+func parseEncryptKey() (err error) {
+	if len(argEncryptKey) == 0 {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(argEncryptKey)
+	if err != nil {
+		return err
+	}
+
+	cfg.EncryptKey, err = bindata.ParseEncryptKey(b)
+
+	return err
+}
+
 // parseOutputPkg will change package name to directory of output, only if
 // output flag is set and package flag is not set.
 func parseOutputPkg() {
@@ -149,6 +149,130 @@ func TestParseArgs(t *testing.T) {
 			Ignore:  defConfig.Ignore,
 			Include: defConfig.Include,
 		},
+	}, {
+		desc: `With "-contenttypes"`,
+		args: []string{
+			"noop",
+			"-contenttypes", `\.tmpl$=text/html; charset=utf-8`,
+			argInputPath,
+		},
+		expConfig: &bindata.Config{
+			Output:      defConfig.Output,
+			Package:     "main",
+			AssetPrefix: bindata.DefAssetPrefixName,
+			Input: []bindata.InputConfig{
+				bindata.CreateInputConfig(argInputPath),
+			},
+			Ignore:  defConfig.Ignore,
+			Include: defConfig.Include,
+			ContentTypes: []bindata.ContentTypeConfig{{
+				Pattern:     regexp.MustCompile(`\.tmpl$`),
+				ContentType: "text/html; charset=utf-8",
+			}},
+		},
+	}, {
+		desc: `With invalid "-contenttypes"`,
+		args: []string{
+			"noop",
+			"-contenttypes", "text/plain",
+			argInputPath,
+		},
+		expErr: ErrInvalidContentTypes,
+	}, {
+		desc: `With "-contenttypes" pattern that contains "\x3d"`,
+		args: []string{
+			"noop",
+			"-contenttypes", `\?v\x3d1$=text/plain`,
+			argInputPath,
+		},
+		expConfig: &bindata.Config{
+			Output:      defConfig.Output,
+			Package:     "main",
+			AssetPrefix: bindata.DefAssetPrefixName,
+			Input: []bindata.InputConfig{
+				bindata.CreateInputConfig(argInputPath),
+			},
+			Ignore:  defConfig.Ignore,
+			Include: defConfig.Include,
+			ContentTypes: []bindata.ContentTypeConfig{{
+				Pattern:     regexp.MustCompile(`\?v\x3d1$`),
+				ContentType: "text/plain",
+			}},
+		},
+	}, {
+		desc: `With "-contenttypes" pattern that contains "="`,
+		args: []string{
+			"noop",
+			"-contenttypes", `\?v=1$=text/plain`,
+			argInputPath,
+		},
+		expErr: ErrInvalidContentTypes,
+	}, {
+		desc: `With invalid "-meta"`,
+		args: []string{
+			"noop",
+			"-meta", `\.png$=cache-control`,
+			argInputPath,
+		},
+		expErr: ErrInvalidMeta,
+	}, {
+		desc: `With "-meta"`,
+		args: []string{
+			"noop",
+			"-meta", `\.png$=cache-control=max-age=3600`,
+			argInputPath,
+		},
+		expConfig: &bindata.Config{
+			Output:      defConfig.Output,
+			Package:     "main",
+			AssetPrefix: bindata.DefAssetPrefixName,
+			Input: []bindata.InputConfig{
+				bindata.CreateInputConfig(argInputPath),
+			},
+			Ignore:  defConfig.Ignore,
+			Include: defConfig.Include,
+			Meta: []bindata.MetaConfig{{
+				Pattern: regexp.MustCompile(`\.png$`),
+				Meta:    map[string]string{"cache-control": "max-age=3600"},
+			}},
+		},
+	}, {
+		desc: `With "-meta" pattern that contains "\x3d"`,
+		args: []string{
+			"noop",
+			"-meta", `(?:\?v\x3d1)$=owner=frontend`,
+			argInputPath,
+		},
+		expConfig: &bindata.Config{
+			Output:      defConfig.Output,
+			Package:     "main",
+			AssetPrefix: bindata.DefAssetPrefixName,
+			Input: []bindata.InputConfig{
+				bindata.CreateInputConfig(argInputPath),
+			},
+			Ignore:  defConfig.Ignore,
+			Include: defConfig.Include,
+			Meta: []bindata.MetaConfig{{
+				Pattern: regexp.MustCompile(`(?:\?v\x3d1)$`),
+				Meta:    map[string]string{"owner": "frontend"},
+			}},
+		},
+	}, {
+		desc: `With "-meta" pattern that contains "="`,
+		args: []string{
+			"noop",
+			"-meta", `(?:\?v=1)$=owner=frontend`,
+			argInputPath,
+		},
+		expErr: ErrInvalidMeta,
+	}, {
+		desc: `With "-meta" empty key`,
+		args: []string{
+			"noop",
+			"-meta", `\.png$==frontend`,
+			argInputPath,
+		},
+		expErr: ErrInvalidMeta,
 	}}
 
 	for _, test := range tests {
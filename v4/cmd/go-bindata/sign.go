@@ -0,0 +1,60 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/ed25519"
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/shuLhan/go-bindata/v4"
+)
+
+const cmdSign = "sign"
+
+// runSign sign the files in each directory, for example the overlay
+// directory, so they can be verified by the generated function
+// VerifySignatureDir.
+func runSign(args []string) {
+	var argKey string
+
+	flags := flag.NewFlagSet(appName+" "+cmdSign, flag.ExitOnError)
+	flags.Usage = func() {
+		lerr.Println("Usage: " + appName + " " + cmdSign + " -key <private key> <directories>")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&argKey, "key", "", "PEM file of ed25519 private key that sign the files.")
+
+	_ = flags.Parse(args)
+
+	if len(argKey) == 0 || flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	key, err := readSignKey(argKey)
+	if err != nil {
+		lerr.Println("bindata: ", err)
+		os.Exit(2)
+	}
+
+	for _, dir := range flags.Args() {
+		err = bindata.SignDir(key, dir)
+		if err != nil {
+			lerr.Println("bindata: ", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// readSignKey read the ed25519 private key from PEM file.
+func readSignKey(file string) (ed25519.PrivateKey, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return bindata.ParseSignKey(b)
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/shuLhan/go-bindata/v4"
+)
+
+const cmdVet = "vet"
+
+// runVet check the call sites of the generated functions in the package
+// directories against the assets in the generated files.
+//
+// It exit with status 1 if one of call site reference missing asset.
+func runVet(args []string) {
+	var argGenerated []string
+
+	flags := flag.NewFlagSet(appName+" "+cmdVet, flag.ExitOnError)
+	flags.Usage = func() {
+		lerr.Println("Usage: " + appName + " " + cmdVet + " [options] [package directories]")
+		flags.PrintDefaults()
+	}
+	flags.Var((*AppendSliceValue)(&argGenerated), "o", "Generated file that contains the assets, can be set multiple times (default \"./bindata.go\").")
+
+	_ = flags.Parse(args)
+
+	if len(argGenerated) == 0 {
+		argGenerated = []string{bindata.DefOutputName}
+	}
+	dirs := flags.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	res, err := bindata.Vet(argGenerated, dirs)
+	if err != nil {
+		lerr.Println("bindata: ", err)
+		os.Exit(2)
+	}
+
+	for _, issue := range res.Missing {
+		lerr.Println(issue)
+	}
+
+	for _, name := range res.Unused {
+		lout.Println("unused asset:", name)
+	}
+	if len(res.Unused) > 0 && len(res.Dynamic) > 0 {
+		lout.Printf("%d call sites with non-constant argument are not checked, the unused assets may be used by them.\n",
+			len(res.Dynamic))
+	}
+
+	if len(res.Missing) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// List of built-in compression names.
+const (
+	CompressionGzip  = "gzip"
+	CompressionZlib  = "zlib"
+	CompressionFlate = "flate"
+	CompressionNone  = "none"
+
+	// CompressionFlateDict compress the assets using flate with the
+	// preset dictionary, that is built from the common substrings of
+	// assets and embedded once in the generated code.
+	CompressionFlateDict = "flatedict"
+)
+
+// CompressionHuffmanOnly is the compression level that only use Huffman
+// encoding, without searching for matches, which is faster but compress
+// less than level 1.
+const CompressionHuffmanOnly = flate.HuffmanOnly
+
+// Compressor define the codec that compress the assets when the code is
+// generated, and the Go code that decompress them at runtime.
+type Compressor interface {
+	// NewWriter return the writer that compress the data written into w
+	// with the level, where 0 is the default level of the codec.
+	NewWriter(w io.Writer, level int) (io.WriteCloser, error)
+
+	// Imports return the list of packages required by the Decoder code.
+	Imports() []string
+
+	// Decoder return the body of generated function
+	//
+	//	func bindataDecompress(r io.Reader) (io.ReadCloser, error)
+	//
+	// that return the reader which decompress the data read from r.
+	Decoder() string
+
+	// ContentEncoding return the HTTP content encoding of the compressed
+	// data, which is send as is by the generated AssetHandler if the
+	// client accept it.
+	// If its empty, the asset is always decompressed before its send.
+	ContentEncoding() string
+}
+
+// nolint: gochecknoglobals
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[string]Compressor{
+		CompressionGzip:  gzipCompressor{},
+		CompressionZlib:  zlibCompressor{},
+		CompressionFlate: flateCompressor{},
+	}
+)
+
+// RegisterCompressor register the custom compressor with the name, so it
+// can be used in the Compression option.
+// It will replace the previous compressor with the same name.
+func RegisterCompressor(name string, comp Compressor) {
+	compressorsMu.Lock()
+	compressors[strings.ToLower(name)] = comp
+	compressorsMu.Unlock()
+}
+
+func lookupCompressor(name string) (comp Compressor, ok bool) {
+	compressorsMu.RLock()
+	comp, ok = compressors[strings.ToLower(name)]
+	compressorsMu.RUnlock()
+	return comp, ok
+}
+
+// validateCompression check the Compression and CompressionLevel options,
+// and set the compressor of assets.
+func (c *Config) validateCompression() error {
+	if len(c.Compression) == 0 {
+		c.Compression = CompressionGzip
+		if c.NoCompress {
+			c.Compression = CompressionNone
+		}
+	}
+	if strings.EqualFold(c.Compression, CompressionNone) {
+		c.NoCompress = true
+		c.compressor = nil
+		c.AdaptiveCompression = false
+		return nil
+	}
+	if c.NoCompress {
+		return fmt.Errorf("option NoCompress can not be used with Compression %q",
+			c.Compression)
+	}
+
+	var (
+		comp Compressor
+		ok   bool
+	)
+	if strings.EqualFold(c.Compression, CompressionFlateDict) {
+		// The dictionary is built after the assets are scanned.
+		comp = &flateDictCompressor{}
+	} else {
+		comp, ok = lookupCompressor(c.Compression)
+		if !ok {
+			return fmt.Errorf("%w %q", ErrCompression, c.Compression)
+		}
+	}
+	if c.CompressionLevel != CompressionHuffmanOnly &&
+		(c.CompressionLevel < 0 || c.CompressionLevel > flate.BestCompression) {
+		return fmt.Errorf("%w %d", ErrCompressionLevel, c.CompressionLevel)
+	}
+
+	c.compressor = comp
+
+	if c.AdaptiveMinSaving != 0 {
+		c.AdaptiveCompression = true
+	}
+	if c.AdaptiveCompression {
+		if c.AdaptiveMinSaving == 0 {
+			c.AdaptiveMinSaving = DefAdaptiveMinSaving
+		}
+		if c.AdaptiveMinSaving < 0 || c.AdaptiveMinSaving >= 1 {
+			return fmt.Errorf("%w %v", ErrAdaptiveMinSaving,
+				c.AdaptiveMinSaving)
+		}
+	}
+	return nil
+}
+
+// compress copy the data from r into w, compressed using the compressor of
+// c.
+func compress(w io.Writer, c *Config, r io.Reader) (err error) {
+	cw, err := c.compressor.NewWriter(w, c.CompressionLevel)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(cw, r)
+	if err != nil {
+		_ = cw.Close()
+		return err
+	}
+
+	return cw.Close()
+}
+
+// flateLevel convert the compression level, where 0 is the default, into
+// the level of package compress/flate.
+func flateLevel(level int) int {
+	if level == 0 {
+		return flate.DefaultCompression
+	}
+	return level
+}
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, flateLevel(level))
+}
+
+func (gzipCompressor) Imports() []string {
+	return []string{"compress/gzip"}
+}
+
+func (gzipCompressor) Decoder() string {
+	return "\treturn gzip.NewReader(r)\n"
+}
+
+func (gzipCompressor) ContentEncoding() string {
+	return "gzip"
+}
+
+type zlibCompressor struct{}
+
+func (zlibCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return zlib.NewWriterLevel(w, flateLevel(level))
+}
+
+func (zlibCompressor) Imports() []string {
+	return []string{"compress/zlib"}
+}
+
+func (zlibCompressor) Decoder() string {
+	return "\treturn zlib.NewReader(r)\n"
+}
+
+// ContentEncoding return "deflate", since the HTTP deflate encoding is the
+// zlib format.
+func (zlibCompressor) ContentEncoding() string {
+	return "deflate"
+}
+
+type flateCompressor struct{}
+
+func (flateCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return flate.NewWriter(w, flateLevel(level))
+}
+
+func (flateCompressor) Imports() []string {
+	return []string{"compress/flate"}
+}
+
+func (flateCompressor) Decoder() string {
+	return "\treturn flate.NewReader(r), nil\n"
+}
+
+// ContentEncoding return empty, since the raw flate data is not a valid HTTP
+// content encoding.
+func (flateCompressor) ContentEncoding() string {
+	return ""
+}
+
+// writeDecompress writes the function bindataDecompress using the Decoder
+// code of compressor.
+func writeDecompress(w io.Writer, c *Config) (err error) {
+	_, err = fmt.Fprintf(w, tmplDecompress, c.Compression, c.compressor.Decoder())
+	if err != nil {
+		return err
+	}
+	if fdc, ok := c.compressor.(*flateDictCompressor); ok {
+		err = fdc.writeDict(w)
+	}
+	return err
+}
+
+const tmplDecompress = `// bindataDecompress return the reader that decompress the %s compressed
+// data from r.
+func bindataDecompress(r io.Reader) (io.ReadCloser, error) {
+%s}
+
+`
@@ -0,0 +1,137 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	data := strings.Repeat("// sample file\n", 16)
+
+	tests := []struct {
+		compression string
+		level       int
+		newReader   func(r io.Reader) (io.Reader, error)
+	}{{
+		compression: CompressionGzip,
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	}, {
+		compression: CompressionGzip,
+		level:       CompressionHuffmanOnly,
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	}, {
+		compression: CompressionZlib,
+		level:       9,
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return zlib.NewReader(r)
+		},
+	}, {
+		compression: CompressionFlate,
+		level:       1,
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return flate.NewReader(r), nil
+		},
+	}}
+
+	for _, test := range tests {
+		t.Logf("%s level %d", test.compression, test.level)
+
+		c := &Config{
+			Compression:      test.compression,
+			CompressionLevel: test.level,
+		}
+		err := c.validateCompression()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		err = compress(&buf, c, strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := test.newReader(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, data, string(got), true)
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	tests := []struct {
+		desc   string
+		cfg    *Config
+		expErr error
+		exp    string
+	}{{
+		desc: `With default`,
+		cfg:  &Config{},
+		exp:  CompressionGzip,
+	}, {
+		desc: `With NoCompress`,
+		cfg: &Config{
+			NoCompress: true,
+		},
+		exp: CompressionNone,
+	}, {
+		desc: `With unknown compression`,
+		cfg: &Config{
+			Compression: "lz4",
+		},
+		expErr: ErrCompression,
+	}, {
+		desc: `With invalid level`,
+		cfg: &Config{
+			CompressionLevel: 10,
+		},
+		expErr: ErrCompressionLevel,
+	}, {
+		desc: `With custom compressor`,
+		cfg: &Config{
+			Compression: "custom",
+		},
+		exp: "custom",
+	}}
+
+	RegisterCompressor("custom", flateCompressor{})
+	defer func() {
+		compressorsMu.Lock()
+		delete(compressors, "custom")
+		compressorsMu.Unlock()
+	}()
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		err := test.cfg.validateCompression()
+		if test.expErr != nil {
+			assert(t, true, errors.Is(err, test.expErr), true)
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, test.exp, test.cfg.Compression, true)
+	}
+}
@@ -5,8 +5,10 @@
 package bindata
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"fmt"
+	"go/token"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -28,6 +30,14 @@ var (
 	ErrNoInput       = errors.New("no input")
 	ErrNoPackageName = errors.New("missing package name")
 	ErrCWD           = errors.New("unable to determine current working directory")
+	ErrBundleName    = errors.New("invalid bundle name")
+	ErrChecksumAlgo  = errors.New("unknown checksum algorithm")
+	ErrSignKey       = errors.New("invalid ed25519 private key")
+	ErrEncryptKey    = errors.New("invalid AES-256 key")
+	ErrCompression   = errors.New("unknown compression")
+
+	ErrCompressionLevel  = errors.New("invalid compression level")
+	ErrAdaptiveMinSaving = errors.New("invalid adaptive minimum saving")
 )
 
 // Config defines a set of options for the asset conversion.
@@ -139,8 +149,46 @@ type Config struct {
 	// NoCompress means the assets are /not/ GZIP compressed before being turned
 	// into Go code. The generated function will automatically unzip
 	// the file data when called. Defaults to false.
+	// Its equal to setting Compression to "none".
 	NoCompress bool
 
+	// Compression define the name of codec that compress the assets:
+	// "gzip", "zlib", "flate", "flatedict", "none", or the name of custom
+	// Compressor that has been registered using RegisterCompressor.
+	// The "flatedict" compress the assets using flate with the preset
+	// dictionary, that is built from the common substrings of assets and
+	// embedded once, and print the size saved compared with gzip if
+	// Verbose is set.
+	// Defaults to "gzip", or "none" if NoCompress is set.
+	// The generated code only import the packages required to decompress
+	// the selected codec.
+	Compression string
+
+	// CompressionLevel define the level of compression, from 1 (best
+	// speed) to 9 (best compression), or CompressionHuffmanOnly.
+	// Defaults to 0, the default level of the codec.
+	CompressionLevel int
+
+	// AdaptiveCompression decide the compression per asset, instead of
+	// compressing all assets.
+	// The asset is stored uncompressed if its already compressed, detected
+	// by its file extension or magic bytes, like PNG, JPEG, WOFF2, and ZIP,
+	// or if the compression saves less than AdaptiveMinSaving of its size.
+	// The generated code record whether each asset is compressed, so the
+	// compressed and uncompressed assets can be mixed.
+	AdaptiveCompression bool
+
+	// AdaptiveMinSaving define the minimum fraction of asset size, between
+	// 0 and 1, that must be saved by compression, otherwise the asset is
+	// stored uncompressed.
+	// Defaults to DefAdaptiveMinSaving.
+	// If its set, AdaptiveCompression is set to true.
+	AdaptiveMinSaving float64
+
+	// compressor is the codec of Compression, or nil if the assets are
+	// not compressed.
+	compressor Compressor
+
 	// Perform a debug build. This generates an asset file, which
 	// loads the asset contents directly from disk at their original
 	// location, instead of embedding the contents in the code.
@@ -172,6 +220,225 @@ type Config struct {
 	// MD5 checksums for files.
 	MD5Checksum bool
 
+	// Checksums define the list of hash algorithms, whose checksum is
+	// calculated for each asset, while its being compressed.
+	// The supported algorithms are "crc32", "md5", "sha1", "sha256",
+	// "sha384", and "sha512".
+	// The hex encoded checksum is returned by the method Checksum of the
+	// asset information.
+	// The "md5" algorithm is equal to MD5Checksum.
+	// The checksums are not calculated on debug and dev mode.
+	Checksums []string
+
+	// Integrity calculate the Subresource Integrity of each asset, using
+	// the SHA-384 hash, which is returned by the method Integrity of the
+	// asset information, for example "sha384-oqVuAfXRKap7f...".
+	// The integrity is not calculated on debug and dev mode.
+	Integrity bool
+
+	// Verify generate the function VerifyAssets, that decompress each
+	// embedded asset and compare its content against the MD5 checksum and
+	// size that are stored when the code is generated.
+	// If its set, MD5Checksum is set to true.
+	// On debug and dev mode, VerifyAssets always return nil.
+	Verify bool
+
+	// VerifyOnLoad verify the content of each asset every time its loaded
+	// by Asset, MustAsset, or AssetInfo, which return an error if the
+	// asset is corrupted.
+	// If the Cache is set, the asset is verified only once, before its
+	// stored in the cache.
+	// If its set, Verify is set to true.
+	VerifyOnLoad bool
+
+	// SignKey define the ed25519 private key that sign the manifest of
+	// assets, which contains the SHA-256 checksum and name of each asset.
+	// The manifest and its signature are embedded, and the generated
+	// function VerifySignature verify them using the public key, along
+	// with the embedded content of each asset.
+	// The generated function VerifySignatureDir verify the directory,
+	// like the overlay directory, that has been signed by SignDir.
+	SignKey ed25519.PrivateKey
+
+	// EncryptKey define the 32 bytes AES-256 key that encrypt the content
+	// of each asset, after its compressed, using AES-256-GCM.
+	// The generated code does not return the content of encrypted asset
+	// until the key is set by the generated function SetAssetKey, or
+	// returned by the function that is set by SetAssetKeyProvider.
+	// The error when decrypting the asset is returned as
+	// *AssetDecryptError.
+	// On debug and dev mode, the assets are read from disk, so they are
+	// not encrypted.
+	// EncryptKey can not be used with NoMemCopy.
+	EncryptKey []byte
+
+	// AssetReader generate the function AssetReader that return the
+	// reader of asset content, which is decompressed while its being read,
+	// instead of loading the whole asset into memory.
+	// If the asset is not compressed, the returned reader also implements
+	// io.Seeker and io.ReaderAt.
+	// AssetReader is always generated if HTTPHandler or Archive is set.
+	AssetReader bool
+
+	// EmptyDirs keep the directories that does not contain any asset, so
+	// they are listed by AssetDir and AssetDirInfo, and recreated by
+	// RestoreAssets.
+	EmptyDirs bool
+
+	// FS generate the function AssetFS that return the embedded assets as
+	// fs.FS.
+	// The returned value also implements fs.ReadDirFS, fs.ReadFileFS, and
+	// fs.StatFS, so it can be used directly with http.FS,
+	// template.ParseFS, or fs.WalkDir.
+	// The generated code require Go 1.16 or later.
+	FS bool
+
+	// HTTPFileSystem generate the function AssetHTTPFileSystem that
+	// return the embedded assets as http.FileSystem.
+	// Each directory in the returned http.FileSystem can be listed, so
+	// the assets can be served directly by http.FileServer.
+	HTTPFileSystem bool
+
+	// HTTPHandler generate the function AssetHandler that return an
+	// http.Handler to serve the embedded assets.
+	// If the asset is stored compressed and the client accept the content
+	// encoding of Compression, "gzip" or "deflate" for zlib, the handler
+	// send the compressed data as is, without decompressing it.
+	// The ETag header is set from the MD5 checksum, if MD5Checksum is set,
+	// or from the modification time and size of asset.
+	// If the client does not accept the content encoding, the asset is
+	// decompressed while its being send, or read from AssetCache if Cache
+	// is set.
+	HTTPHandler bool
+
+	// Archive generate the functions WriteTar and WriteZip that write the
+	// assets tree as tar or zip archive, keeping the mode and modification
+	// time of each asset.
+	Archive bool
+
+	// RestoreWithOptions generate the function RestoreAssetsWithOptions,
+	// that restore the assets using the overwrite policy, mirror mode,
+	// permission mask, and parallelism.
+	// Each file is written atomically, and the asset name that contains
+	// ".." is rejected.
+	RestoreWithOptions bool
+
+	// Cache generate the AssetCache that memoize the content of assets,
+	// so each asset is decompressed only once, until its evicted from the
+	// cache.
+	// The cache is not used on debug and dev mode.
+	Cache bool
+
+	// CacheMaxSize define the maximum size of AssetCache in bytes.
+	// If the total size of cached assets is larger than this value, the
+	// least recently used assets are evicted.
+	// Zero means the cache size is not limited.
+	CacheMaxSize int64
+
+	// Overlay generate the function SetOverlayDir that set the directory
+	// on disk that override the embedded assets.
+	// Asset, AssetInfo, and AssetDir check the overlay directory first,
+	// and fall back to the embedded assets.
+	Overlay bool
+
+	// OverlayEnv define the name of environment variable that contains
+	// the overlay directory, which is read when the program started.
+	// If its set, Overlay is set to true.
+	OverlayEnv string
+
+	// ContentType detect the MIME type of each asset when the code is
+	// generated, and store it in the asset information, which is returned
+	// by the generated function AssetContentType.
+	// The MIME type is detected from the file extension, or from the
+	// content using http.DetectContentType if the extension is unknown.
+	// The function AssetDataURI is generated too, to return the asset as
+	// data URI.
+	ContentType bool
+
+	// ContentTypes override the detected MIME type of the assets whose
+	// path match the pattern.
+	// The first matched pattern is used.
+	// If its not empty, ContentType is set to true.
+	ContentTypes []ContentTypeConfig
+
+	// Meta define the metadata of the assets whose path match the
+	// pattern.
+	// The metadata is stored in the asset information, which is returned
+	// by its Sys method and by the generated function AssetMeta.
+	// If the asset path match several patterns, their metadata are merged,
+	// where the later pattern override the same key from the previous one.
+	Meta []MetaConfig
+
+	// MetaFiles read the metadata of asset from the sidecar file, which
+	// is the asset path followed by ".meta.json", for example
+	// "logo.png.meta.json" for "logo.png".
+	// The sidecar file contains JSON object with string values, that
+	// override the metadata from Meta.
+	// The sidecar files are not embedded.
+	MetaFiles bool
+
+	// NameConsts generate the constant for each asset name, so the
+	// missing asset is caught by the compiler instead of at runtime.
+	// The constant name is the asset name, converted into Go identifier,
+	// prefixed with "AssetName", or with the bundle name followed by
+	// "Name" in bundle mode.
+	// The duplicate constant name is suffixed with number, as in the
+	// name of asset function.
+	NameConsts bool
+
+	// HashedNames compute the hash of each asset content, and register
+	// the asset under its hashed name too, for example "app.3f2a9c1b.js"
+	// for "app.js", so it can be cached forever by the browser.
+	// The hash is the first 8 characters of hex encoded SHA-256.
+	// The generated functions AssetHashedName and AssetOriginalName map
+	// the original name to the hashed name and vice versa.
+	// The hashed names are not listed by AssetNames and AssetDir.
+	HashedNames bool
+
+	// HashedNamesManifest define the path of JSON file, which is written
+	// along with the generated code, that map the original name of each
+	// asset to its hashed name, for the frontend build tools.
+	// If its set, HashedNames is set to true.
+	HashedNamesManifest string
+
+	// RewriteHashedNames rewrite the relative references inside CSS
+	// url() and HTML src and href attributes, that refer to other assets,
+	// to their hashed names before the assets are embedded.
+	// The hashed name of CSS and HTML asset is computed from its rewritten
+	// content.
+	// The reference that can not be resolved to an asset is kept as is,
+	// with a warning printed to the standard error if Verbose is set.
+	// The references are not rewritten on debug and dev mode.
+	// If its set, HashedNames is set to true.
+	RewriteHashedNames bool
+
+	// Bundle define the name of variable that hold the assets as a value of
+	// type *Bundle, instead of package-level tables.
+	// The API is provided by the methods of Bundle, so several bundles
+	// can be generated into the same package.
+	// The name of asset functions are prefixed with the bundle name too.
+	// If FS is set, the method FS is generated for Bundle and
+	// BundleLayers.
+	// The other optional API, like HTTPHandler, Cache, Overlay, or
+	// EncryptKey, is generated as the methods of Bundle, where the state
+	// like the cache, overlay directory, or key is kept by each bundle.
+	// Bundle can not be used with Split.
+	Bundle string
+
+	// BundleWrapper generate the package-level functions, like Asset and
+	// AssetDir, that call the methods of the bundle.
+	// Only one bundle in a package can set this option.
+	BundleWrapper bool
+
+	// NoBundleType does not generate the Bundle type, its methods, and the
+	// other code that is shared by all bundles.
+	// This option is used when generating another bundle into the package
+	// that already contains the Bundle type, and it must be generated with
+	// the same Debug, Dev, NoCompress, Compression, NoMemCopy, AssetReader,
+	// and the other options that generate the methods of Bundle.
+	// NoBundleType can not be used with Compression "flatedict".
+	NoBundleType bool
+
 	// Verbose flag to display verbose output.
 	Verbose bool
 }
@@ -212,6 +479,31 @@ func (c *Config) validateInput() (err error) {
 	return nil
 }
 
+// validateBundle check the bundle name and the options that can not be used
+// with bundle.
+func (c *Config) validateBundle() error {
+	if len(c.Bundle) == 0 {
+		return nil
+	}
+	if !token.IsIdentifier(c.Bundle) || c.Bundle == "Bundle" {
+		return fmt.Errorf("%w %q", ErrBundleName, c.Bundle)
+	}
+
+	if c.Split {
+		return errors.New("option Split can not be used with bundle")
+	}
+
+	// The preset dictionary is written once along with the Bundle type,
+	// so the assets of another bundle can not be compressed with its own
+	// dictionary.
+	_, withDict := c.compressor.(*flateDictCompressor)
+	if withDict && c.NoBundleType {
+		return fmt.Errorf("option Compression %s can not be used with NoBundleType",
+			CompressionFlateDict)
+	}
+	return nil
+}
+
 // validateOutput will check if output is valid.
 //
 // (1) If output is empty, set the output directory to,
@@ -276,6 +568,50 @@ func (c *Config) validate() (err error) {
 		return ErrNoPackageName
 	}
 
+	if len(c.ContentTypes) > 0 {
+		c.ContentType = true
+	}
+	if len(c.OverlayEnv) > 0 {
+		c.Overlay = true
+	}
+	if len(c.HashedNamesManifest) > 0 || c.RewriteHashedNames {
+		c.HashedNames = true
+	}
+
+	if c.VerifyOnLoad {
+		c.Verify = true
+	}
+	if c.Verify {
+		c.MD5Checksum = true
+	}
+
+	if c.SignKey != nil && len(c.SignKey) != ed25519.PrivateKeySize {
+		return ErrSignKey
+	}
+	if c.EncryptKey != nil {
+		if len(c.EncryptKey) != encryptKeySize {
+			return ErrEncryptKey
+		}
+		if c.NoMemCopy {
+			return fmt.Errorf("option NoMemCopy can not be used with EncryptKey")
+		}
+	}
+
+	err = c.validateCompression()
+	if err != nil {
+		return err
+	}
+
+	err = c.validateChecksums()
+	if err != nil {
+		return err
+	}
+
+	err = c.validateBundle()
+	if err != nil {
+		return err
+	}
+
 	err = c.validateInput()
 	if err != nil {
 		return
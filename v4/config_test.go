@@ -136,3 +136,70 @@ test:
 		assert(t, c.expOutput, c.cfg.Output, true)
 	}
 }
+
+func TestValidateBundle(t *testing.T) {
+	tests := []struct {
+		desc   string
+		cfg    *Config
+		expErr string
+	}{{
+		desc: `Without bundle`,
+		cfg: &Config{
+			Cache: true,
+		},
+	}, {
+		desc: `With valid name`,
+		cfg: &Config{
+			Bundle:        "WebAssets",
+			BundleWrapper: true,
+		},
+	}, {
+		desc: `With invalid name`,
+		cfg: &Config{
+			Bundle: "web-assets",
+		},
+		expErr: `invalid bundle name "web-assets"`,
+	}, {
+		desc: `With name of the type`,
+		cfg: &Config{
+			Bundle: "Bundle",
+		},
+		expErr: `invalid bundle name "Bundle"`,
+	}, {
+		desc: `With unsupported option`,
+		cfg: &Config{
+			Bundle: "WebAssets",
+			Split:  true,
+		},
+		expErr: `option Split can not be used with bundle`,
+	}, {
+		desc: `With optional API`,
+		cfg: &Config{
+			Bundle:       "WebAssets",
+			HTTPHandler:  true,
+			Cache:        true,
+			Overlay:      true,
+			HashedNames:  true,
+			NoBundleType: true,
+		},
+	}, {
+		desc: `With flatedict and NoBundleType`,
+		cfg: &Config{
+			Bundle:       "WebAssets",
+			NoBundleType: true,
+			compressor:   &flateDictCompressor{},
+		},
+		expErr: `option Compression flatedict can not be used with NoBundleType`,
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		err := test.cfg.validateBundle()
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.expErr, "", true)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// uniqueName return the name, or the name suffixed with number if its
+// already exist in known.
+// The returned name is registered in known, so it will not be returned again.
+func uniqueName(known map[string]int, name string) string {
+	num, ok := known[name]
+	if !ok {
+		known[name] = 2
+		return name
+	}
+	for {
+		unique := fmt.Sprintf("%s_%d", name, num)
+		num++
+		if _, ok = known[unique]; !ok {
+			known[name] = num
+			known[unique] = 2
+			return unique
+		}
+	}
+}
+
+// nameConstPrefix return the prefix for the constant of asset name.
+// In bundle mode, the prefix is the bundle name followed by "Name", so the
+// constants of several bundles in the same package does not collide.
+func nameConstPrefix(c *Config) string {
+	if len(c.Bundle) > 0 {
+		return c.Bundle + "Name"
+	}
+	return "AssetName"
+}
+
+// writeNameConsts writes the constant for each asset name.
+// The constants are declared in the same package scope as the functions of
+// assets and their data, so their names are made unique against those
+// names too.
+func writeNameConsts(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	prefix := nameConstPrefix(c)
+	known := make(map[string]int, 2*len(keys))
+	names := make([]string, 0, len(keys))
+	longest := 0
+
+	for _, key := range keys {
+		funcName := toc[key].funcName
+		uniqueName(known, funcName)
+		uniqueName(known, funcName+"Bytes")
+		uniqueName(known, funcName+"Info")
+	}
+
+	for _, key := range keys {
+		name := uniqueName(known, prefix+normalize(key))
+		if len(name) > longest {
+			longest = len(name)
+		}
+		names = append(names, name)
+	}
+
+	_, err = io.WriteString(w, tmplNameConsts)
+	if err != nil {
+		return err
+	}
+
+	for x, key := range keys {
+		_, err = fmt.Fprintf(w, "\t%-*s = %q\n", longest, names[x], key)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, ")\n")
+
+	return err
+}
+
+const tmplNameConsts = `
+// List of asset names, that can be used as the argument of Asset and the
+// other functions, so the missing asset is caught by the compiler.
+const (
+`
@@ -0,0 +1,75 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteNameConsts(t *testing.T) {
+	tests := []struct {
+		desc string
+		cfg  *Config
+		keys []string
+		toc  map[string]*asset
+		exp  string
+	}{{
+		desc: `With duplicate name`,
+		cfg:  &Config{},
+		keys: []string{"a.b", "a/b", "a/b.c", "file name"},
+		toc: map[string]*asset{
+			"a.b":       {funcName: "bindataAB"},
+			"a/b":       {funcName: "bindataAB_2"},
+			"a/b.c":     {funcName: "bindataABC"},
+			"file name": {funcName: "bindataFilename"},
+		},
+		exp: tmplNameConsts +
+			"\tAssetNameAB       = \"a.b\"\n" +
+			"\tAssetNameAB_2     = \"a/b\"\n" +
+			"\tAssetNameABC      = \"a/b.c\"\n" +
+			"\tAssetNameFilename = \"file name\"\n" +
+			")\n",
+	}, {
+		desc: `With bundle`,
+		cfg: &Config{
+			Bundle: "web",
+		},
+		keys: []string{"index.html"},
+		toc: map[string]*asset{
+			"index.html": {funcName: "bindatawebIndexHtml"},
+		},
+		exp: tmplNameConsts +
+			"\twebNameIndexHtml = \"index.html\"\n" +
+			")\n",
+	}, {
+		desc: `With name of asset function`,
+		cfg:  &Config{},
+		keys: []string{"assetName.data/foo.txt", "assetName/data/foo.txt", "data/foo.txt"},
+		toc: map[string]*asset{
+			"assetName.data/foo.txt": {funcName: "AssetNameDataFooTxt"},
+			"assetName/data/foo.txt": {funcName: "AssetNameDataFooTxt_2"},
+			"data/foo.txt":           {funcName: "DataFooTxt"},
+		},
+		exp: tmplNameConsts +
+			"\tAssetNameAssetNameDataFooTxt   = \"assetName.data/foo.txt\"\n" +
+			"\tAssetNameAssetNameDataFooTxt_2 = \"assetName/data/foo.txt\"\n" +
+			"\tAssetNameDataFooTxt_3          = \"data/foo.txt\"\n" +
+			")\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		var buf bytes.Buffer
+
+		err := writeNameConsts(&buf, test.cfg, test.keys, test.toc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert(t, test.exp, buf.String(), true)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// contentTypeImports contains list of packages required by the generated
+// AssetContentType and AssetDataURI.
+// nolint: gochecknoglobals
+var contentTypeImports = []string{
+	"encoding/base64", "mime", "net/http", "os", "path", "strings",
+}
+
+// contentTypes contains the content type of common file extensions.
+// The table is used instead of mime.TypeByExtension, so the generated code
+// does not depend on the MIME database of the system where its generated.
+// nolint: gochecknoglobals
+var contentTypes = map[string]string{
+	".avif":  "image/avif",
+	".css":   "text/css; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".gif":   "image/gif",
+	".htm":   "text/html; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/x-icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".map":   "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".otf":   "font/otf",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".ttf":   "font/ttf",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".xml":   "text/xml; charset=utf-8",
+}
+
+// ContentTypeConfig define the content type of the assets whose path match
+// the pattern.
+type ContentTypeConfig struct {
+	Pattern     *regexp.Regexp
+	ContentType string
+}
+
+// detectContentType return the content type of asset.
+//
+// (1) If the asset path match one of ContentTypes pattern, its content type
+// is returned.
+// (2) If the asset extension is known, the content type of extension is
+// returned.
+// (3) Otherwise, the content type is detected from the first 512 bytes of
+// asset using http.DetectContentType.
+func detectContentType(c *Config, ast *asset) (ctype string, err error) {
+	// (1)
+	for _, ct := range c.ContentTypes {
+		if ct.Pattern.MatchString(ast.path) {
+			return ct.ContentType, nil
+		}
+	}
+
+	// (2)
+	ctype, ok := contentTypes[strings.ToLower(filepath.Ext(ast.path))]
+	if ok {
+		return ctype, nil
+	}
+
+	// (3)
+	fd, err := os.Open(ast.path)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(fd, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		_ = fd.Close()
+		return "", err
+	}
+
+	err = fd.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// writeContentType writes the functions AssetContentType and AssetDataURI
+// in the scope s.
+func writeContentType(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplContentType)
+}
+
+const tmplFileInfoContentType = `func (fi bindataFileInfo) ContentType() string {
+	return fi.contentType
+}
+`
+
+const tmplContentType = `
+//
+// AssetContentType return the MIME type of asset, that is detected from its
+// file extension or its content when the code is generated.
+//
+{{.Decl "AssetContentType"}}(name string) (string, error) {
+	info, err := {{.Ref "AssetInfo"}}(name)
+	if err != nil {
+		return "", err
+	}
+	if ex, ok := info.(interface{ ContentType() string }); ok {
+		return ex.ContentType(), nil
+	}
+
+	// The content type of directory, or the asset that is not generated
+	// by this package, is detected on the fly.
+	data, err := {{.Ref "Asset"}}(name)
+	if err != nil {
+		return "", err
+	}
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if len(ctype) == 0 {
+		ctype = http.DetectContentType(data)
+	}
+	return ctype, nil
+}
+
+//
+// AssetDataURI return the content of asset as data URI with base64 encoding,
+// for example to inline the small image in HTML page or e-mail.
+//
+{{.Decl "AssetDataURI"}}(name string) (string, error) {
+	ctype, err := {{.Ref "AssetContentType"}}(name)
+	if err != nil {
+		return "", err
+	}
+	data, err := {{.Ref "Asset"}}(name)
+	if err != nil {
+		return "", err
+	}
+	ctype = strings.ReplaceAll(ctype, " ", "")
+
+	return "data:" + ctype + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+`
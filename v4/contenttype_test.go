@@ -0,0 +1,49 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDetectContentType(t *testing.T) {
+	cfg := &Config{
+		ContentTypes: []ContentTypeConfig{{
+			Pattern:     regexp.MustCompile(`\.tmpl$`),
+			ContentType: "text/html; charset=utf-8",
+		}},
+	}
+
+	tests := []struct {
+		path string
+		exp  string
+	}{{
+		path: "web/index.tmpl",
+		exp:  "text/html; charset=utf-8",
+	}, {
+		path: "web/style/MAIN.CSS",
+		exp:  "text/css; charset=utf-8",
+	}, {
+		path: "web/logo.svg",
+		exp:  "image/svg+xml",
+	}, {
+		path: "testdata/in/file name",
+		exp:  "text/plain; charset=utf-8",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.path)
+
+		got, err := detectContentType(cfg, &asset{path: test.path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, test.exp, got, true)
+	}
+
+	_, err := detectContentType(cfg, &asset{path: "testdata/in/x"})
+	assert(t, "open testdata/in/x: no such file or directory", err.Error(), true)
+}
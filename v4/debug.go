@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 )
 
 // writeOneFileDebug writes the debug code file for each file (when splited file).
@@ -24,8 +25,12 @@ func writeOneFileDebug(w io.Writer, c *Config, ast *asset) error {
 }
 
 // writeDebug writes the debug code file for single file.
-func writeDebug(w io.Writer, c *Config, keys []string, toc map[string]*asset) error {
-	err := writeDebugHeader(w)
+func writeDebug(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	if c.NoBundleType && len(c.Bundle) > 0 {
+		err = writeDebugFileHeader(w, c.Dev)
+	} else {
+		err = writeDebugHeader(w, c)
+	}
 	if err != nil {
 		return err
 	}
@@ -62,30 +67,22 @@ func writeDebugFileHeader(w io.Writer, dev bool) error {
 
 // writeDebugHeader writes output file headers for sigle file.
 // This targets debug builds.
-func writeDebugHeader(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
-)
+func writeDebugHeader(w io.Writer, c *Config) (err error) {
+	imports := newGoImports("fmt", "io/ioutil", "os", "path/filepath",
+		"strings")
 
-// bindataRead reads the given file from disk. It returns an error on failure.
-func bindataRead(path, name string) ([]byte, error) {
-	buf, err := ioutil.ReadFile(path)
+	imports.add(nodeInfoImports...)
+	imports.add(queryImports...)
+	addReaderImports(c, imports)
+	addFeatureImports(c, imports)
+
+	err = imports.write(w)
 	if err != nil {
-		err = fmt.Errorf("Error reading asset %%s at %%s: %%v", name, path, err)
+		return err
 	}
-	return buf, err
-}
 
-type asset struct {
-	bytes []byte
-	info  os.FileInfo
-}
+	_, err = io.WriteString(w, tmplDebugHeader)
 
-`)
 	return err
 }
 
@@ -98,7 +95,12 @@ func writeDebugAsset(w io.Writer, c *Config, ast *asset) error {
 		pathExpr = fmt.Sprintf("filepath.Join(rootDir, %q)", ast.name)
 	}
 
-	_, err := fmt.Fprintf(w, `// %s reads file data from disk. It returns an error on failure.
+	infoExpr, err := debugInfoExpr(c, ast)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// %s reads file data from disk. It returns an error on failure.
 func %sBytes() ([]byte, error) {
 	asset, err := %s()
 	if asset == nil {
@@ -120,10 +122,120 @@ func %s() (*asset, error) {
 		err = fmt.Errorf("Error reading asset info %%s at %%s: %%v", name, path, err)
 	}
 
-	a := &asset{bytes: bytes, info: fi}
+	a := &asset{bytes: bytes, info: %s}
 	return a, err
 }
 
-`, ast.funcName, ast.funcName, ast.funcName, ast.funcName, pathExpr, ast.name)
+`, ast.funcName, ast.funcName, ast.funcName, ast.funcName, pathExpr, ast.name,
+		infoExpr)
+	return err
+}
+
+// debugInfoExpr return the expression of asset information.
+// If the content type or metadata of assets is generated, the file
+// information from disk is wrapped with bindataDebugInfo that contains them.
+func debugInfoExpr(c *Config, ast *asset) (expr string, err error) {
+	if !c.ContentType && !c.withMeta() {
+		return "fi", nil
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("bindataDebugInfo{\n\t\tFileInfo: fi,\n")
+	if c.ContentType {
+		ctype, err := detectContentType(c, ast)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "\t\tcontentType: %q,\n", ctype)
+	}
+	if c.withMeta() {
+		meta, err := assetMeta(c, ast)
+		if err != nil {
+			return "", err
+		}
+		if meta != nil {
+			fmt.Fprintf(&sb, "\t\tmeta: %s,\n", formatMeta(meta, 2))
+		}
+	}
+	sb.WriteString("\t}")
+
+	return sb.String(), nil
+}
+
+// writeDebugInfo writes the type bindataDebugInfo, that add the content
+// type and metadata to the file information from disk.
+func writeDebugInfo(w io.Writer, c *Config) (err error) {
+	_, err = io.WriteString(w, tmplDebugInfo)
+	if err != nil {
+		return err
+	}
+	if c.ContentType {
+		_, err = io.WriteString(w, "\tcontentType string\n")
+		if err != nil {
+			return err
+		}
+	}
+	if c.withMeta() {
+		_, err = io.WriteString(w, "\tmeta        map[string]string\n")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "}\n")
+	if err != nil {
+		return err
+	}
+	if c.ContentType {
+		_, err = io.WriteString(w, tmplDebugInfoContentType)
+		if err != nil {
+			return err
+		}
+	}
+	if c.withMeta() {
+		_, err = io.WriteString(w, tmplDebugInfoSys)
+	}
 	return err
 }
+
+const tmplDebugInfo = `
+// bindataDebugInfo add the information, that is collected when the code is
+// generated, to the file information from disk.
+type bindataDebugInfo struct {
+	os.FileInfo
+`
+
+const tmplDebugInfoContentType = `
+func (fi bindataDebugInfo) ContentType() string {
+	return fi.contentType
+}
+`
+
+const tmplDebugInfoSys = `
+// Sys return the metadata of asset as map[string]string, or nil if the asset
+// does not have metadata.
+// The returned map is shared, it should not be modified.
+func (fi bindataDebugInfo) Sys() interface{} {
+	if fi.meta == nil {
+		return nil
+	}
+	return fi.meta
+}
+`
+
+const tmplDebugHeader = `
+// bindataRead reads the given file from disk. It returns an error on failure.
+func bindataRead(path, name string) ([]byte, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset %s at %s: %v", name, path, err)
+	}
+	return buf, err
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+`
@@ -0,0 +1,346 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"compress/flate"
+	"container/heap"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// List of limits when building the preset dictionary.
+const (
+	// dictMaxSize is the maximum size of dictionary, which is the window
+	// size of flate.
+	dictMaxSize = 32 << 10
+
+	// dictSampleSize is the maximum bytes that are sampled from each
+	// asset.
+	dictSampleSize = 4 << 10
+
+	// dictSampleTotal is the maximum bytes that are sampled from all
+	// assets.
+	dictSampleTotal = 512 << 10
+
+	// dictGramLen is the length of substring that is counted in the
+	// samples.
+	dictGramLen = 8
+
+	// dictSegmentLen is the maximum length of segment, which is the
+	// maximum length of match in flate.
+	dictSegmentLen = 258
+)
+
+// flateDictCompressor compress the assets using flate with the preset
+// dictionary that is built from the assets.
+type flateDictCompressor struct {
+	dict []byte
+}
+
+func (fdc *flateDictCompressor) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return flate.NewWriterDict(w, flateLevel(level), fdc.dict)
+}
+
+func (fdc *flateDictCompressor) Imports() []string {
+	return []string{"compress/flate"}
+}
+
+func (fdc *flateDictCompressor) Decoder() string {
+	return "\treturn flate.NewReaderDict(r, _bindataDict), nil\n"
+}
+
+// ContentEncoding return empty, since the client does not have the
+// dictionary.
+func (fdc *flateDictCompressor) ContentEncoding() string {
+	return ""
+}
+
+// writeDict writes the dictionary that is embedded once and shared by all
+// assets.
+func (fdc *flateDictCompressor) writeDict(w io.Writer) (err error) {
+	_, err = io.WriteString(w, tmplDict)
+	if err != nil {
+		return err
+	}
+	_, err = (&stringWriter{Writer: w}).Write(fdc.dict)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\")\n\n")
+	return err
+}
+
+// buildDict build the preset dictionary from the samples of assets.
+//
+// Each sample is split into segments, the runs of bytes that are covered by
+// substrings which occur in at least two samples.
+// The segments are selected greedily by their score, the sum of the number
+// of samples that contain each substring of segment that is not in the
+// dictionary yet, until the dictionary is full.
+// The segment with highest score is placed at the end of dictionary,
+// closest to the compressed data.
+func buildDict(keys []string, toc map[string]*asset) (dict []byte, err error) {
+	samples, err := sampleAssets(keys, toc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the number of samples that contain each substring.
+	grams := make(map[string]int)
+	for _, sample := range samples {
+		seen := make(map[string]struct{})
+		for x := 0; x+dictGramLen <= len(sample); x++ {
+			gram := string(sample[x : x+dictGramLen])
+			if _, ok := seen[gram]; ok {
+				continue
+			}
+			seen[gram] = struct{}{}
+			grams[gram]++
+		}
+	}
+
+	seen := make(map[string]struct{})
+	segs := &dictSegments{}
+	for _, sample := range samples {
+		for _, seg := range commonSegments(sample, grams) {
+			if _, ok := seen[seg]; ok {
+				continue
+			}
+			seen[seg] = struct{}{}
+			segs.list = append(segs.list, &dictSegment{value: seg})
+		}
+	}
+
+	covered := make(map[string]struct{})
+	for _, seg := range segs.list {
+		seg.score = seg.gain(grams, covered)
+	}
+	heap.Init(segs)
+
+	var (
+		selected []string
+		size     int
+	)
+	for segs.Len() > 0 && size < dictMaxSize {
+		seg := heap.Pop(segs).(*dictSegment)
+
+		// The score is decreased when other segment is selected, so
+		// re-evaluate it before selecting.
+		score := seg.gain(grams, covered)
+		if score != seg.score {
+			seg.score = score
+			if score > 0 {
+				heap.Push(segs, seg)
+			}
+			continue
+		}
+		if score < len(seg.value) || size+len(seg.value) > dictMaxSize {
+			continue
+		}
+
+		for x := 0; x+dictGramLen <= len(seg.value); x++ {
+			covered[seg.value[x:x+dictGramLen]] = struct{}{}
+		}
+		selected = append(selected, seg.value)
+		size += len(seg.value)
+	}
+
+	dict = make([]byte, 0, size)
+	for x := len(selected) - 1; x >= 0; x-- {
+		dict = append(dict, selected[x]...)
+	}
+	return dict, nil
+}
+
+// dictSegment is the candidate segment of dictionary.
+type dictSegment struct {
+	value string
+	score int
+}
+
+// gain return the sum of the number of samples that contain each substring
+// of segment that is not covered by the dictionary yet.
+func (seg *dictSegment) gain(grams map[string]int, covered map[string]struct{}) (score int) {
+	for x := 0; x+dictGramLen <= len(seg.value); x++ {
+		gram := seg.value[x : x+dictGramLen]
+		if _, ok := covered[gram]; ok {
+			continue
+		}
+		score += grams[gram]
+	}
+	return score
+}
+
+// dictSegments implements heap.Interface, ordered by the highest score,
+// and then by value for reproducible dictionary.
+type dictSegments struct {
+	list []*dictSegment
+}
+
+func (segs *dictSegments) Len() int {
+	return len(segs.list)
+}
+
+func (segs *dictSegments) Less(x, y int) bool {
+	if segs.list[x].score != segs.list[y].score {
+		return segs.list[x].score > segs.list[y].score
+	}
+	return segs.list[x].value < segs.list[y].value
+}
+
+func (segs *dictSegments) Swap(x, y int) {
+	segs.list[x], segs.list[y] = segs.list[y], segs.list[x]
+}
+
+func (segs *dictSegments) Push(v interface{}) {
+	segs.list = append(segs.list, v.(*dictSegment))
+}
+
+func (segs *dictSegments) Pop() interface{} {
+	last := segs.list[len(segs.list)-1]
+	segs.list = segs.list[:len(segs.list)-1]
+	return last
+}
+
+// sampleAssets read the head of each asset, except the asset that is
+// already compressed by its file extension, until the total size of samples
+// reach the limit.
+func sampleAssets(keys []string, toc map[string]*asset) (samples [][]byte, err error) {
+	var total int
+
+	for _, key := range keys {
+		ast := toc[key]
+		if _, ok := compressedExts[strings.ToLower(filepath.Ext(ast.name))]; ok {
+			continue
+		}
+
+		fd, err := openAsset(ast)
+		if err != nil {
+			return nil, err
+		}
+
+		sample := make([]byte, dictSampleSize)
+		n, err := io.ReadFull(fd, sample)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			_ = fd.Close()
+			return nil, err
+		}
+
+		err = fd.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		sample = sample[:n]
+		if n == 0 || hasCompressedMagic(sample) {
+			continue
+		}
+		if total+n > dictSampleTotal {
+			break
+		}
+		samples = append(samples, sample)
+		total += n
+	}
+	return samples, nil
+}
+
+// commonSegments return the runs of sample that are covered by the
+// substrings which occur in at least two samples, split into segments of
+// dictSegmentLen.
+func commonSegments(sample []byte, grams map[string]int) (segments []string) {
+	covered := make([]bool, len(sample))
+	for x := 0; x+dictGramLen <= len(sample); x++ {
+		if grams[string(sample[x:x+dictGramLen])] < 2 {
+			continue
+		}
+		for y := x; y < x+dictGramLen; y++ {
+			covered[y] = true
+		}
+	}
+
+	start := -1
+	for x := 0; x <= len(sample); x++ {
+		if x < len(sample) && covered[x] {
+			if start < 0 {
+				start = x
+			}
+			continue
+		}
+		for ; start >= 0 && start < x; start += dictSegmentLen {
+			end := start + dictSegmentLen
+			if end > x {
+				end = x
+			}
+			if end-start >= dictGramLen {
+				segments = append(segments, string(sample[start:end]))
+			}
+		}
+		start = -1
+	}
+	return segments
+}
+
+// reportDict writes the total size of compressed assets with the dictionary,
+// including the dictionary itself, compared with compressing them using
+// gzip without dictionary.
+func reportDict(w io.Writer, c *Config, fdc *flateDictCompressor, keys []string, toc map[string]*asset) (err error) {
+	var withDict, withGzip byteCounter
+
+	withDict = byteCounter(len(fdc.dict))
+
+	for _, key := range keys {
+		ast := toc[key]
+		if !ast.compressed {
+			continue
+		}
+
+		err = compressCount(&withDict, c, ast)
+		if err != nil {
+			return err
+		}
+
+		gzipConfig := &Config{
+			CompressionLevel: c.CompressionLevel,
+			compressor:       gzipCompressor{},
+		}
+		err = compressCount(&withGzip, gzipConfig, ast)
+		if err != nil {
+			return err
+		}
+	}
+
+	saved := int64(withGzip) - int64(withDict)
+	var percent float64
+	if withGzip > 0 {
+		percent = float64(saved) * 100 / float64(withGzip)
+	}
+
+	_, err = fmt.Fprintf(w, "flate dictionary: %d bytes, compressed assets: %d bytes, with gzip: %d bytes, saved: %d bytes (%.1f%%)\n",
+		len(fdc.dict), int64(withDict), int64(withGzip), saved, percent)
+	return err
+}
+
+// compressCount add the size of compressed asset into bc.
+func compressCount(bc *byteCounter, c *Config, ast *asset) (err error) {
+	fd, err := openAsset(ast)
+	if err != nil {
+		return err
+	}
+
+	err = compress(bc, c, fd)
+	if err != nil {
+		_ = fd.Close()
+		return err
+	}
+
+	return fd.Close()
+}
+
+const tmplDict = `// _bindataDict is the preset dictionary of flate, that is shared by all
+// assets.
+var _bindataDict = []byte("`
@@ -0,0 +1,122 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"compress/flate"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommonSegments(t *testing.T) {
+	grams := map[string]int{
+		"abcdefgh": 2,
+		"bcdefghi": 2,
+		"12345678": 1,
+	}
+
+	got := commonSegments([]byte("xxabcdefghiyy12345678"), grams)
+
+	assert(t, []string{"abcdefghi"}, got, true)
+}
+
+func TestBuildDict(t *testing.T) {
+	paths, err := filepath.Glob("testdata/dict/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		keys = make([]string, 0, len(paths))
+		toc  = make(map[string]*asset, len(paths))
+	)
+	for _, path := range paths {
+		keys = append(keys, path)
+		toc[path] = &asset{path: path, name: path, compressed: true}
+	}
+
+	dict, err := buildDict(keys, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dict) == 0 || len(dict) > dictMaxSize {
+		t.Fatalf("got dictionary size %d", len(dict))
+	}
+	if !bytes.Contains(dict, []byte(`"type": "product"`)) {
+		t.Fatalf("dictionary does not contain the common substring: %q", dict)
+	}
+
+	// Each asset must be compressed smaller with the dictionary, and
+	// decompressed back using the same dictionary.
+	c := &Config{
+		Compression: CompressionFlateDict,
+	}
+	err = c.validateCompression()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.compressor.(*flateDictCompressor).dict = dict
+
+	for _, path := range paths {
+		exp, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var withDict, withoutDict bytes.Buffer
+
+		err = compress(&withDict, c, bytes.NewReader(exp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = compress(&withoutDict, &Config{compressor: flateCompressor{}}, bytes.NewReader(exp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withDict.Len() >= withoutDict.Len() {
+			t.Fatalf("%s: compressed size with dictionary %d, without %d",
+				path, withDict.Len(), withoutDict.Len())
+		}
+
+		got, err := ioutil.ReadAll(flate.NewReaderDict(&withDict, dict))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, string(exp), string(got), true)
+	}
+}
+
+func TestReportDict(t *testing.T) {
+	c := &Config{
+		Compression: CompressionFlateDict,
+	}
+	err := c.validateCompression()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fdc := c.compressor.(*flateDictCompressor)
+	fdc.dict = []byte(strings.Repeat("// sample file\n", 2))
+
+	keys := []string{"a", "b"}
+	toc := map[string]*asset{
+		"a": {content: []byte("// sample file\n"), compressed: true},
+		"b": {content: []byte("// sample file\n"), compressed: true},
+	}
+
+	var buf bytes.Buffer
+	err = reportDict(&buf, c, fdc, keys, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "flate dictionary: 30 bytes, ") {
+		t.Fatalf("got report %q", buf.String())
+	}
+}
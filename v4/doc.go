@@ -89,6 +89,28 @@ even increase the size of the data.
 
 The default behaviour of the program is to use compression.
 
+The Compression option select the codec that compress the assets: "gzip",
+"zlib", "flate", or "none", along with the CompressionLevel from 1 (best
+speed) to 9 (best compression), or CompressionHuffmanOnly.
+The generated code only import the packages required by the selected codec.
+The custom codec can be registered using RegisterCompressor, by implementing
+the Compressor interface, which provides the encoder and the Go code that
+decode it.
+
+When the AdaptiveCompression option is set, the compression is decided per
+asset.
+The asset that is already compressed, detected by its file extension or magic
+bytes, or whose compression saves less than AdaptiveMinSaving of its size, is
+stored uncompressed, while the other assets are compressed.
+
+The "flatedict" compression is suitable for many small assets with the
+similar content.
+The preset dictionary is built from the substrings that are common between
+the assets, and embedded once in the generated code, while each asset is
+compressed using flate with that dictionary.
+The size of dictionary and the size saved compared to gzip are reported to
+the standard error if `Verbose` is set.
+
 # Path prefix stripping
 
 The keys used in the `_bindata` map are the same as the input file name passed
@@ -121,6 +143,198 @@ format is specified at build time with the appropriate tags.
 The tags are appended to a `// +build` line in the beginning of the output file
 and must follow the build tags syntax specified by the go tool.
 
+# Directories
+
+The directories are recorded with their mode and modification time, so
+`AssetInfo` accept the directory name and `RestoreAssets` recreate the
+directories with their original mode.
+The function `AssetDirInfo` return the file information of files and
+directories below a directory, sorted by name.
+The directory that does not contain any asset is embedded only if the
+`EmptyDirs` option is set.
+
+# Querying assets
+
+The generated functions `AssetWalk`, `AssetGlob`, and `AssetNamesWithPrefix`
+query the assets names by walking the assets tree, by pattern using the
+path.Match syntax, or by prefix, in sorted order.
+
+When the `NameConsts` option is set, the generated code contains a constant
+for each asset name, for example `AssetNamePubStyleFooCss`, so the missing
+asset is caught by the compiler.
+
+# Restoring assets with options
+
+When the `RestoreWithOptions` option is set, the generated code provides the
+function `RestoreAssetsWithOptions`, which restore the assets using the
+overwrite policy, mirror mode, permission mask, and parallelism defined in
+`RestoreOptions`.
+Each file is written atomically, and the asset name that contains ".." is
+rejected.
+
+# Exporting assets as archive
+
+When the `Archive` option is set, the generated code provides the functions
+`WriteTar` and `WriteZip`, which write the assets tree as tar or zip archive,
+keeping the mode and modification time of each asset.
+
+# Streaming assets
+
+When the `AssetReader` option is set, the generated function `AssetReader`
+returns a reader for the asset content.
+The content is decompressed while it is being read, instead of loading the
+whole asset into memory.
+If the asset is not compressed, the returned reader also implements io.Seeker
+and io.ReaderAt, which read the embedded data without copying it.
+
+# Accessing assets through fs.FS
+
+When the `FS` option is set, the generated code also provides the function
+`AssetFS`, which return the embedded assets as fs.FS.
+The returned value implements fs.ReadDirFS, fs.ReadFileFS, and fs.StatFS too,
+so it can be passed directly to http.FS, template.ParseFS, or fs.WalkDir.
+The generated code require Go 1.16 or later.
+
+# Serving assets with net/http
+
+When the `HTTPFileSystem` option is set, the generated code also provides the
+function `AssetHTTPFileSystem`, which return the embedded assets as
+http.FileSystem.
+The directories in the returned http.FileSystem can be listed, so the assets
+can be served directly by http.FileServer.
+
+When the `HTTPHandler` option is set, the generated code provides the function
+`AssetHandler`, which return an http.Handler that serve the embedded assets.
+If the asset is stored compressed and the client accept its content encoding,
+"gzip" or "deflate" for zlib, the compressed data is send as is, without
+decompressing it, otherwise the asset is decompressed while its being send,
+or read from the AssetCache if `Cache` option is set.
+The ETag header is set from the MD5 checksum, if `MD5Checksum` option is set,
+or from the asset modification time and size, and the Last-Modified header is
+set from the asset modification time.
+
+# Detecting the content type of assets
+
+When the `ContentType` option is set, the MIME type of each asset is detected
+from its file extension, or from its content using http.DetectContentType,
+when the code is generated.
+The `ContentTypes` option override the MIME type of the assets whose path
+match the pattern.
+The MIME type is returned by the generated function `AssetContentType`, and
+used by `AssetHandler` to set the Content-Type header.
+The generated function `AssetDataURI` return the asset as data URI.
+
+# Checksums and Subresource Integrity
+
+The `Checksums` option define the list of hash algorithms, whose hex encoded
+checksum is calculated for each asset and returned by the method Checksum of
+asset information.
+The `Integrity` option calculate the Subresource Integrity of each asset,
+using the SHA-384 hash, which is returned by the method Integrity of asset
+information.
+
+# Verifying the embedded assets
+
+When the `Verify` option is set, the generated function `VerifyAssets`
+decompress each embedded asset and compare its content against the MD5
+checksum and size that are stored when the code is generated.
+The `VerifyOnLoad` option verify each asset every time its loaded by `Asset`,
+`MustAsset`, or `AssetInfo`.
+
+# Signing the assets
+
+When the `SignKey` option is set, the manifest of assets, which contains the
+SHA-256 checksum and name of each asset, is signed using the ed25519 private
+key, and embedded along with its signature.
+The generated function `VerifySignature` verify the signature using the
+public key, and the embedded content of each asset against the manifest.
+The directory of files, like the overlay directory, can be signed using
+`SignDir`, and verified at runtime using the generated function
+`VerifySignatureDir`, which also reject the file that is not in the
+manifest.
+
+# Encrypting the assets
+
+When the `EncryptKey` option is set, the content of each asset is encrypted
+after its compressed, using AES-256-GCM with the 32 bytes key.
+The generated code does not return the content of encrypted asset until the
+key is set using the generated function `SetAssetKey`, or returned by the
+function that is set using `SetAssetKeyProvider`.
+The asset that can not be decrypted return the error that wrap
+`*AssetDecryptError`.
+
+# Attaching metadata to assets
+
+The `Meta` option define the metadata, a map of string key and value, for the
+assets whose path match the pattern.
+When the `MetaFiles` option is set, the metadata is also read from the
+sidecar file "<asset>.meta.json", which is not embedded.
+The metadata is returned by the Sys method of asset information, and by the
+generated function `AssetMeta`.
+
+# Content-hashed asset names
+
+When the `HashedNames` option is set, each asset is also registered under its
+name with the hash of its content, for example "app.3f2a9c1b.js" for
+"app.js", so it can be cached forever by the browser.
+The generated functions `AssetHashedName` and `AssetOriginalName` map the
+original name to the hashed name and vice versa.
+The `HashedNamesManifest` option write the JSON file that map the original
+name of each asset to its hashed name.
+The `RewriteHashedNames` option rewrite the relative references inside CSS
+url() and HTML src and href attributes to the hashed names of assets that
+they refer to, and print a warning for the reference that could not be
+resolved if `Verbose` is set.
+
+# Caching decompressed assets
+
+When the `Cache` option is set, the generated code provides the variable
+`AssetCache`, which memoize the decompressed content of each asset, so it is
+decompressed only once, even if its requested by concurrent callers.
+The total size of cache can be limited by the `CacheMaxSize` option, where
+the least recently used assets are evicted first.
+All assets can be loaded into cache concurrently using
+`AssetCache.Preload`, and removed from cache using `AssetCache.Purge`.
+The cache is not used in debug mode.
+
+# Overriding assets with overlay directory
+
+When the `Overlay` option is set, the generated code provides the function
+`SetOverlayDir`, which set a directory on disk that override the embedded
+assets.
+The asset is read from the overlay directory first, and fall back to the
+embedded one if the file does not exist there.
+The `OverlayEnv` option set the name of environment variable where the overlay
+directory is read when the program start.
+
+# Multiple bundles in one package
+
+When the `Bundle` option is set, the assets are generated as a value of type
+`*Bundle` with the given name, whose methods provide the same API as the
+package-level functions.
+Another bundle can be generated into the same package with the `NoBundleType`
+option, which skip the `Bundle` type and its methods.
+The `BundleWrapper` option generates the package-level functions that call the
+methods of the bundle.
+
+The other optional API, like `HTTPHandler`, `Cache`, `Overlay`, or
+`EncryptKey`, is generated as the methods of `Bundle`, where the state like
+the cache, overlay directory, or key is kept by each bundle.
+
+Several bundles can be stacked using `BundleLayers`, so the assets in the
+upper bundle override the assets with the same name in the lower bundles,
+and the content of directories are merged from all bundles.
+
+# Checking the call sites of assets
+
+The function Vet, and the "go-bindata vet" command, check the calls of the
+generated functions with constant argument against the assets in the
+generated files.
+It report the call site that reference missing asset, and list the assets
+that is not referenced by any call site.
+The call is checked only if it resolve to the generated package, so the
+method with the same name on another type is ignored.
+
 # Splitting generated file
 
 When you want to embed big files or plenty of files, then the generated output
@@ -0,0 +1,299 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// encryptKeySize is the size of AES-256 key, in bytes.
+const encryptKeySize = 32
+
+// encryptNonceLabel is the label for deriving the key of nonce from the
+// encryption key, so the encryption key is not used directly by HMAC.
+const encryptNonceLabel = "bindata-nonce"
+
+// encryptImports contains list of packages required by the generated
+// functions that decrypt the assets.
+// nolint: gochecknoglobals
+var encryptImports = []string{
+	"crypto/aes", "crypto/cipher", "errors", "fmt", "sync",
+}
+
+// ParseEncryptKey parse the hex encoded AES-256 key, for example generated
+// by "openssl rand -hex 32".
+// The leading and trailing white spaces are ignored.
+func ParseEncryptKey(b []byte) (key []byte, err error) {
+	key, err = hex.DecodeString(string(bytes.TrimSpace(b)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrEncryptKey, err)
+	}
+	if len(key) != encryptKeySize {
+		return nil, fmt.Errorf("%w: expecting %d bytes, got %d",
+			ErrEncryptKey, encryptKeySize, len(key))
+	}
+	return key, nil
+}
+
+// encrypt seal the data of asset using AES-256-GCM, with the asset name as
+// additional data, so the encrypted data can not be swapped between assets.
+// The nonce is derived from HMAC-SHA256 of the name and data, using the
+// nonce key that is derived from the encryption key, so the output is
+// reproducible, and prepended to the encrypted data.
+func encrypt(key []byte, name string, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(encryptNonceLabel))
+	nonceKey := mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, nonceKey)
+	_, _ = mac.Write([]byte(name))
+	_, _ = mac.Write([]byte{0})
+	_, _ = mac.Write(data)
+	nonce := mac.Sum(nil)[:aead.NonceSize()]
+
+	return aead.Seal(nonce, nonce, data, []byte(name)), nil
+}
+
+// encryptAsset writes the asset data that is compressed, if the asset is
+// compressed, and then encrypted.
+func encryptAsset(w io.Writer, c *Config, ast *asset, r io.Reader) (err error) {
+	var buf bytes.Buffer
+
+	if ast.compressed {
+		err = compress(&buf, c, r)
+	} else {
+		_, err = io.Copy(&buf, r)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := encrypt(c.EncryptKey, ast.name, buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `var _%s = []byte("`, ast.funcName)
+	if err != nil {
+		return err
+	}
+
+	_, err = (&stringWriter{Writer: w}).Write(data)
+	if err != nil {
+		return err
+	}
+
+	if !ast.compressed {
+		_, err = fmt.Fprintf(w, tmplFuncEncryptNocompress, ast.funcName,
+			decryptFunc(c), ast.funcName, ast.name)
+	} else {
+		// In adaptive mode, bindataRead need to know that the data is
+		// compressed.
+		var adaptive string
+		if c.AdaptiveCompression {
+			adaptive = ", true"
+		}
+		_, err = fmt.Fprintf(w, tmplFuncEncryptCompress, ast.funcName,
+			decryptFunc(c), ast.funcName, ast.name, ast.name, adaptive)
+	}
+	return err
+}
+
+// decryptFunc return the function that decrypt the embedded data of asset,
+// which use the key of bundle if Bundle is set.
+func decryptFunc(c *Config) string {
+	return "_bindataKey" + c.Bundle + ".decrypt"
+}
+
+// writeEncrypt writes the errors of decryption, the type of key, and the
+// functions that set the key in the scope s.
+func writeEncrypt(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplEncrypt)
+}
+
+const tmplFuncEncryptCompress = `")
+
+func %sBytes() ([]byte, error) {
+	data, err := %s(_%s, %q)
+	if err != nil {
+		return nil, err
+	}
+	return bindataRead(data, %q%s)
+}
+
+`
+
+const tmplFuncEncryptNocompress = `")
+
+func %sBytes() ([]byte, error) {
+	return %s(_%s, %q)
+}
+
+`
+
+// tmplRawOpenEncrypt is the part of function rawOpen that decrypt the
+// embedded data.
+const tmplRawOpenEncrypt = `	data, err := %s(raw.data, cannonicalName)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return bytes.NewReader(data), raw.info, %s, nil
+}
+`
+
+// tmplEncrypt contains the errors of decryption, the key of assets, and the
+// functions that set the key.
+const tmplEncrypt = tmplEncryptError + tmplEncryptKey + tmplEncryptNewAEAD
+
+const tmplEncryptError = `
+//
+// ErrAssetKeyNotSet is returned when the encrypted asset is read before its
+// key is set using SetAssetKey or returned by the key provider.
+//
+var ErrAssetKeyNotSet = errors.New("the key of assets is not set")
+
+//
+// AssetDecryptError is the error returned when the encrypted asset can not
+// be decrypted, because its key is not set, the key is wrong, or the
+// embedded data has been modified.
+//
+type AssetDecryptError struct {
+	Name string
+	Err  error
+}
+
+func (e *AssetDecryptError) Error() string {
+	return "asset " + e.Name + " can't be decrypted: " + e.Err.Error()
+}
+
+// Unwrap return the underlying error.
+func (e *AssetDecryptError) Unwrap() error {
+	return e.Err
+}
+`
+
+const tmplEncryptKey = `
+// bindataKey contains the cipher that decrypt the assets, and the function
+// that provide its key.
+type bindataKey struct {
+	sync.Mutex
+	aead     cipher.AEAD
+	provider func() ([]byte, error)
+}
+{{- if not .Bundle}}
+
+// _bindataKey contains the key that decrypt the assets.
+// nolint: gochecknoglobals
+var _bindataKey bindataKey
+{{- end}}
+
+//
+// SetAssetKey set the 32 bytes AES-256 key that decrypt the assets.
+// The encrypted assets can not be read until the key is set, or returned by
+// the key provider.
+//
+{{.Decl "SetAssetKey"}}(key []byte) error {
+	aead, err := bindataNewAEAD(key)
+	if err != nil {
+		return err
+	}
+	{{.Field "key"}}.Lock()
+	{{.Field "key"}}.aead = aead
+	{{.Field "key"}}.Unlock()
+	return nil
+}
+
+//
+// SetAssetKeyProvider set the function that return the key of assets, which
+// is called when the asset is read and the key has not been set.
+// Once the provider return the key without error, the key is kept and the
+// provider is not called anymore.
+//
+{{.Decl "SetAssetKeyProvider"}}(provider func() ([]byte, error)) {
+	{{.Field "key"}}.Lock()
+	{{.Field "key"}}.provider = provider
+	{{.Field "key"}}.Unlock()
+}
+
+// current return the cipher from the key that has been set, or from the key
+// provider.
+func (k *bindataKey) current() (cipher.AEAD, error) {
+	k.Lock()
+	aead, provider := k.aead, k.provider
+	k.Unlock()
+
+	if aead != nil {
+		return aead, nil
+	}
+	if provider == nil {
+		return nil, ErrAssetKeyNotSet
+	}
+
+	// The provider is called without holding the lock, so it can set
+	// the key, and a slow provider does not block the other readers.
+	key, err := provider()
+	if err != nil {
+		return nil, err
+	}
+	aead, err = bindataNewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	k.Lock()
+	defer k.Unlock()
+	if k.aead == nil {
+		k.aead = aead
+	}
+	return k.aead, nil
+}
+
+// decrypt open the encrypted data of asset name, which is prefixed with the
+// nonce.
+func (k *bindataKey) decrypt(data []byte, name string) ([]byte, error) {
+	aead, err := k.current()
+	if err != nil {
+		return nil, &AssetDecryptError{Name: name, Err: err}
+	}
+	n := aead.NonceSize()
+	if len(data) < n {
+		return nil, &AssetDecryptError{Name: name, Err: errors.New("data is too short")}
+	}
+	plain, err := aead.Open(nil, data[:n], data[n:], []byte(name))
+	if err != nil {
+		return nil, &AssetDecryptError{Name: name, Err: err}
+	}
+	return plain, nil
+}
+`
+
+const tmplEncryptNewAEAD = `
+func bindataNewAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid key size %d, expecting 32", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+`
@@ -0,0 +1,85 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+func TestParseEncryptKey(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/encrypt/key.hex")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ParseEncryptKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, encryptKeySize, len(key), true)
+
+	for _, in := range []string{"not a key", "3f9a6c1d"} {
+		_, err = ParseEncryptKey([]byte(in))
+		assert(t, true, errors.Is(err, ErrEncryptKey), true)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	key := make([]byte, encryptKeySize)
+	data := []byte("// sample file\n")
+
+	got, err := encrypt(key, "in/test.asset", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The output must be reproducible, so the generated code does not
+	// change if the assets does not change.
+	again, err := encrypt(key, "in/test.asset", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, got, again, true)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := aead.NonceSize()
+
+	plain, err := aead.Open(nil, got[:n], got[n:], []byte("in/test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, data, plain, true)
+
+	// The encrypted data can not be opened as another asset.
+	_, err = aead.Open(nil, got[:n], got[n:], []byte("in/a/test.asset"))
+	assert(t, true, err != nil, true)
+
+	// The same content with different name must use different nonce.
+	other, err := encrypt(key, "in/a/test.asset", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, got[:n], other[:n], false)
+
+	// The nonce is not derived from the encryption key directly.
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte("in/test.asset"))
+	_, _ = mac.Write([]byte{0})
+	_, _ = mac.Write(data)
+	assert(t, mac.Sum(nil)[:n], got[:n], false)
+}
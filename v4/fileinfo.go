@@ -0,0 +1,331 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// nodeInfoImports contains list of packages required by the generated
+// functions that return the file information of node in the assets tree.
+// nolint: gochecknoglobals
+var nodeInfoImports = []string{
+	"os", "path", "sort", "strings", "time",
+}
+
+// writeNodeInfo writes the table of directories, and the functions and types
+// that return the file information of files and directories in the assets
+// tree.
+func writeNodeInfo(w io.Writer, c *Config, dirs []*asset) (err error) {
+	if c.Debug || c.Dev {
+		err = writeDirsDebug(w, c, dirs)
+	} else {
+		err = writeDirsRelease(w, c, dirs)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = writeNodeFuncs(w, c, genScope{})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, tmplNodeInfoTypes)
+
+	return err
+}
+
+// writeNodeFuncs writes the functions that return the node in the assets
+// tree and the file information of files and directories, in the scope s.
+func writeNodeFuncs(w io.Writer, c *Config, s genScope) error {
+	hooks := lookupHooks(c)
+	tmpl := tmplDirRelease + tmplNodeInfo + tmplNodeInfoRelease
+	if c.Debug || c.Dev {
+		hooks["dirPath"] = "dirPath"
+		if c.Dev {
+			hooks["dirPath"] = "filepath.Join(rootDir, dirPath)"
+		}
+		tmpl = tmplDirDebug + tmplNodeInfo + tmplNodeInfoDebug
+	}
+	return writeScoped(w, s.withHooks(hooks), tmpl+tmplNodeEntries)
+}
+
+// getLongestDirNameLen return the length of the longest directory name.
+func getLongestDirNameLen(dirs []*asset) (longest int) {
+	for _, dir := range dirs {
+		if len(dir.name) > longest {
+			longest = len(dir.name)
+		}
+	}
+	return longest
+}
+
+// writeDirsDebug writes the table of directories, mapped to its path on
+// disk.
+func writeDirsDebug(w io.Writer, c *Config, dirs []*asset) (err error) {
+	_, err = io.WriteString(w, tmplDirsDebug)
+	if err != nil {
+		return err
+	}
+
+	err = writeDirsDebugEntries(w, c, dirs)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+
+	return err
+}
+
+// writeDirsDebugEntries writes the entries in the table of directories,
+// mapped to its path on disk.
+func writeDirsDebugEntries(w io.Writer, c *Config, dirs []*asset) (err error) {
+	longestNameLen := getLongestDirNameLen(dirs)
+
+	for _, dir := range dirs {
+		path := dir.name
+		if !c.Dev {
+			path = filepath.Join(c.cwd, dir.path)
+		}
+		_, err = fmt.Fprintf(w, "\t%q:%*s%q,\n", dir.name,
+			longestNameLen-len(dir.name)+1, "", path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDirsRelease writes the table of directories, mapped to its file
+// information.
+func writeDirsRelease(w io.Writer, c *Config, dirs []*asset) (err error) {
+	_, err = io.WriteString(w, tmplDirsRelease)
+	if err != nil {
+		return err
+	}
+
+	err = writeDirsReleaseEntries(w, c, dirs)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+
+	return err
+}
+
+// writeDirsReleaseEntries writes the entries in the table of directories,
+// mapped to its file information.
+func writeDirsReleaseEntries(w io.Writer, c *Config, dirs []*asset) (err error) {
+	longestNameLen := getLongestDirNameLen(dirs)
+
+	for _, dir := range dirs {
+		mode := uint(dir.fi.Mode())
+		modTime := dir.fi.ModTime().Unix()
+		if c.NoMetadata {
+			mode = uint(os.ModeDir | 0755)
+			modTime = 0
+		}
+		if c.ModTime > 0 {
+			modTime = c.ModTime
+		}
+
+		_, err = fmt.Fprintf(w,
+			"\t%q:%*s{name: %q, mode: os.FileMode(%d), modTime: time.Unix(%d, 0)},\n",
+			dir.name, longestNameLen-len(dir.name)+1, "",
+			dir.name, mode, modTime)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const tmplDirsDebug = `
+//
+// _bindataDirs is a table, holding the path of each directory on disk, mapped
+// to its name.
+//
+var _bindataDirs = map[string]string{
+`
+
+const tmplDirDebug = `
+// {{.Name "dir"}} return the file information of directory name.
+// The directory that is not recorded in the table of directories, for
+// example the parent of the input directory, has the default mode and zero
+// modification time.
+{{.Decl "dir"}}(name string) (os.FileInfo, error) {
+	dirPath, ok := {{.Field "dirs"}}[name]
+	if !ok {
+		return bindataDirInfo{name: name, mode: os.ModeDir | 0755}, nil
+	}
+	return os.Stat({{.Hook "dirPath"}})
+}
+`
+
+const tmplDirsRelease = `
+//
+// _bindataDirs is a table, holding the file information of each directory,
+// mapped to its name.
+//
+var _bindataDirs = map[string]bindataDirInfo{
+`
+
+const tmplDirRelease = `
+// {{.Name "dir"}} return the file information of directory name.
+// The directory that is not recorded in the table of directories, for
+// example the parent of the input directory, has the default mode and zero
+// modification time.
+{{.Decl "dir"}}(name string) (os.FileInfo, error) {
+	di, ok := {{.Field "dirs"}}[name]
+	if !ok {
+		return bindataDirInfo{name: name, mode: os.ModeDir | 0755}, nil
+	}
+	return di, nil
+}
+`
+
+const tmplNodeInfo = `
+//
+// AssetDirInfo returns the file information of files and directories below a
+// certain directory, sorted by name.
+// It returns an error if the directory could not be found.
+//
+{{.Decl "AssetDirInfo"}}(name string) ([]os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	node := {{.Ref "node"}}(cannonicalName)
+	if node == nil || node.Func != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return {{.Ref "nodeEntries"}}(cannonicalName, node)
+}
+
+// {{.Name "node"}} return the node in the assets tree for the given name, or
+// nil if its not exist.
+{{.Decl "node"}}(name string) *bintree {
+	node := {{.Field "tree"}}
+	if len(name) == 0 {
+		return node
+	}
+	for _, p := range strings.Split(name, "/") {
+		node = node.Children[p]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+`
+
+// tmplNodeInfoDebug return the file information of asset from the asset
+// function, which read the file on disk.
+const tmplNodeInfoDebug = `
+// {{.Name "nodeInfo"}} return the file information of node in the assets
+// tree, with Name() set to the base of name.
+{{.Decl "nodeInfo"}}(name string, node *bintree) (os.FileInfo, error) {
+	if node.Func == nil {
+		fi, err := {{.Ref "dir"}}(name)
+		if err != nil {
+			return nil, err
+		}
+		return bindataBaseInfo{FileInfo: fi, name: path.Base(name)}, nil
+	}
+	a, err := node.Func()
+	if err != nil {
+		return nil, err
+	}
+	return bindataBaseInfo{FileInfo: a.info, name: path.Base(name)}, nil
+}
+`
+
+// tmplNodeInfoRelease return the file information of asset from the table
+// of embedded data, so the asset is not decompressed only to read its
+// information.
+// The lookup of overlay directory is inserted by the hook "nodeInfo".
+const tmplNodeInfoRelease = `
+// {{.Name "nodeInfo"}} return the file information of node in the assets
+// tree, with Name() set to the base of name.
+// The asset content is not read.
+{{.Decl "nodeInfo"}}(name string, node *bintree) (os.FileInfo, error) {
+	if node.Func == nil {
+		fi, err := {{.Ref "dir"}}(name)
+		if err != nil {
+			return nil, err
+		}
+		return bindataBaseInfo{FileInfo: fi, name: path.Base(name)}, nil
+	}{{.Hook "nodeInfo"}}
+	raw, ok := {{.Field "raw"}}[name]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return bindataBaseInfo{FileInfo: raw.info, name: path.Base(name)}, nil
+}
+`
+
+const tmplNodeEntries = `
+// {{.Name "nodeEntries"}} return the file information of all children of
+// node, sorted by name.
+{{.Decl "nodeEntries"}}(name string, node *bintree) ([]os.FileInfo, error) {
+	names := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		names = append(names, childName)
+	}
+	sort.Strings(names)
+
+	list := make([]os.FileInfo, 0, len(names))
+	for _, childName := range names {
+		fi, err := {{.Ref "nodeInfo"}}(path.Join(name, childName), node.Children[childName])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, fi)
+	}
+	return list, nil
+}
+`
+
+const tmplNodeInfoTypes = `
+// bindataBaseInfo override the Name of asset information with the base
+// name of the asset.
+type bindataBaseInfo struct {
+	os.FileInfo
+	name string
+}
+
+func (fi bindataBaseInfo) Name() string {
+	return fi.name
+}
+
+// bindataDirInfo define the file information for directory.
+type bindataDirInfo struct {
+	name    string
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (di bindataDirInfo) Name() string {
+	return di.name
+}
+func (di bindataDirInfo) Size() int64 {
+	return 0
+}
+func (di bindataDirInfo) Mode() os.FileMode {
+	return di.mode
+}
+func (di bindataDirInfo) ModTime() time.Time {
+	return di.modTime
+}
+func (di bindataDirInfo) IsDir() bool {
+	return true
+}
+func (di bindataDirInfo) Sys() interface{} {
+	return nil
+}
+`
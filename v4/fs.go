@@ -0,0 +1,243 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// fsImports contains list of packages required by the generated fs.FS
+// implementation.
+// nolint: gochecknoglobals
+var fsImports = []string{
+	"bytes", "errors", "io", "io/fs", "os", "path", "strings",
+}
+
+// writeFS writes the function that return the embedded assets as fs.FS, in
+// the scope s.
+// The fs.FS implementation read the assets using the functions Asset,
+// AssetInfo, and AssetDirInfo, so the hashed name and the overlay directory
+// are served too.
+func writeFS(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplFS)
+}
+
+const tmplFS = `{{if .Bundle}}
+//
+// FS returns the assets in the bundle as fs.FS.
+// The returned value also implements fs.ReadDirFS, fs.ReadFileFS, and
+// fs.StatFS.
+//
+func (b *Bundle) FS() fs.FS {
+	return bindataFS{src: b}
+}
+
+//
+// FS returns the assets in all bundles as fs.FS, where each name is served
+// by the top most bundle that contains it.
+// The returned value also implements fs.ReadDirFS, fs.ReadFileFS, and
+// fs.StatFS.
+//
+func (layers BundleLayers) FS() fs.FS {
+	return bindataFS{src: layers}
+}
+{{- else}}
+//
+// AssetFS returns the embedded assets as fs.FS.
+// The returned value also implements fs.ReadDirFS, fs.ReadFileFS, and
+// fs.StatFS.
+//
+func AssetFS() fs.FS {
+	return bindataFS{src: bindataAssets{}}
+}
+
+// bindataAssets read the embedded assets using the package-level functions.
+type bindataAssets struct{}
+
+func (bindataAssets) Asset(name string) ([]byte, error) {
+	return Asset(name)
+}
+func (bindataAssets) AssetInfo(name string) (os.FileInfo, error) {
+	return AssetInfo(name)
+}
+func (bindataAssets) AssetDirInfo(name string) ([]os.FileInfo, error) {
+	return AssetDirInfo(name)
+}
+{{- end}}
+
+// bindataSource is the set of assets that can be accessed as fs.FS.
+type bindataSource interface {
+	Asset(name string) ([]byte, error)
+	AssetInfo(name string) (os.FileInfo, error)
+	AssetDirInfo(name string) ([]os.FileInfo, error)
+}
+
+type bindataFS struct {
+	src bindataSource
+}
+
+// lookup return the asset name and the file information of name, with
+// Name() set to the base of name.
+func (fsys bindataFS) lookup(op, name string) (string, fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	// The backslash is converted to slash by Asset and AssetInfo, so it
+	// must be rejected here.
+	if strings.ContainsRune(name, '\\') {
+		return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	assetName := name
+	if name == "." {
+		assetName = ""
+	}
+	fi, err := fsys.src.AssetInfo(assetName)
+	if err != nil {
+		return "", nil, bindataFSError(op, name, err)
+	}
+	return assetName, bindataBaseInfo{FileInfo: fi, name: path.Base(name)}, nil
+}
+
+// bindataFSError return err as *fs.PathError with the given op and name.
+func bindataFSError(op, name string, err error) error {
+	if pe, ok := err.(*fs.PathError); ok {
+		err = pe.Err
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// Open opens the named file or directory.
+func (fsys bindataFS) Open(name string) (fs.File, error) {
+	assetName, fi, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return &bindataFSDir{fsys: fsys, name: name, assetName: assetName, info: fi}, nil
+	}
+	data, err := fsys.src.Asset(assetName)
+	if err != nil {
+		return nil, bindataFSError("open", name, err)
+	}
+	return &bindataFSFile{Reader: bytes.NewReader(data), info: fi}, nil
+}
+
+// ReadDir reads the named directory and returns a list of directory entries
+// sorted by filename.
+func (fsys bindataFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	assetName, fi, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	dir := &bindataFSDir{fsys: fsys, name: name, assetName: assetName, info: fi}
+	return dir.ReadDir(-1)
+}
+
+// ReadFile reads the named file and returns a copy of its contents.
+func (fsys bindataFS) ReadFile(name string) ([]byte, error) {
+	assetName, fi, err := fsys.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	data, err := fsys.src.Asset(assetName)
+	if err != nil {
+		return nil, bindataFSError("read", name, err)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Stat returns a FileInfo describing the named file or directory.
+func (fsys bindataFS) Stat(name string) (fs.FileInfo, error) {
+	_, fi, err := fsys.lookup("stat", name)
+	return fi, err
+}
+
+// bindataFSDir implements fs.ReadDirFile for a directory in the assets.
+type bindataFSDir struct {
+	fsys      bindataFS
+	name      string
+	assetName string
+	info      fs.FileInfo
+	entries   []fs.DirEntry
+	offset    int
+}
+
+func (d *bindataFSDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+func (d *bindataFSDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+func (d *bindataFSDir) Close() error {
+	return nil
+}
+
+// ReadDir returns the next n entries of directory, sorted by filename.
+func (d *bindataFSDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		list, err := d.fsys.src.AssetDirInfo(d.assetName)
+		if err != nil {
+			return nil, bindataFSError("readdir", d.name, err)
+		}
+		d.entries = make([]fs.DirEntry, 0, len(list))
+		for _, fi := range list {
+			d.entries = append(d.entries, bindataFSDirEntry{info: fi})
+		}
+	}
+
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
+` + tmplFSTypes
+
+const tmplFSTypes = `
+// bindataFSDirEntry implements fs.DirEntry from fs.FileInfo.
+type bindataFSDirEntry struct {
+	info fs.FileInfo
+}
+
+func (de bindataFSDirEntry) Name() string {
+	return de.info.Name()
+}
+func (de bindataFSDirEntry) IsDir() bool {
+	return de.info.IsDir()
+}
+func (de bindataFSDirEntry) Type() fs.FileMode {
+	return de.info.Mode().Type()
+}
+func (de bindataFSDirEntry) Info() (fs.FileInfo, error) {
+	return de.info, nil
+}
+
+// bindataFSFile implements fs.File, io.Seeker, and io.ReaderAt for an asset.
+type bindataFSFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *bindataFSFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+func (f *bindataFSFile) Close() error {
+	return nil
+}
+`
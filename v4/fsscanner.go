@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 )
 
 // byName implement sort.Interface for []os.FileInfo based on Name()
@@ -24,6 +25,7 @@ type fsScanner struct {
 	knownFuncs  map[string]int
 	visitedDirs map[string]bool
 	assets      map[string]*asset
+	dirs        map[string]*asset
 	depth       int
 }
 
@@ -43,14 +45,21 @@ func (fss *fsScanner) Reset() {
 	fss.knownFuncs = make(map[string]int)
 	fss.visitedDirs = make(map[string]bool)
 	fss.assets = make(map[string]*asset, 0)
+	fss.dirs = make(map[string]*asset)
 	fss.depth = 0
 }
 
 // isIgnored will return,
+// (0) true, if `path` is the sidecar file of asset metadata,
 // (1) true, if `path` is matched with one of ignore-pattern,
 // (2) false, if `path` is matched with one of include-pattern,
 // (3) true, if include-pattern is defined but no matched found.
 func (fss *fsScanner) isIgnored(path string) bool {
+	// (0)
+	if fss.cfg.isMetaFile(path) {
+		return true
+	}
+
 	// (1)
 	for _, re := range fss.cfg.Ignore {
 		if re.MatchString(path) {
@@ -94,13 +103,7 @@ func (fss *fsScanner) addAsset(path, realPath string, fi os.FileInfo) {
 		return
 	}
 
-	num, ok := fss.knownFuncs[asset.funcName]
-	if ok {
-		fss.knownFuncs[asset.funcName] = num + 1
-		asset.funcName = fmt.Sprintf("%s_%d", asset.funcName, num)
-	} else {
-		fss.knownFuncs[asset.funcName] = 2
-	}
+	asset.funcName = uniqueName(fss.knownFuncs, asset.funcName)
 
 	if fss.cfg.Verbose {
 		fmt.Printf("+ %+v\n", path)
@@ -109,6 +112,28 @@ func (fss *fsScanner) addAsset(path, realPath string, fi os.FileInfo) {
 	fss.assets[name] = asset
 }
 
+// addDir will add new directory based on path and its file info.
+// The directory at the root of assets tree, where its name is empty after
+// the prefix is removed, is not added.
+func (fss *fsScanner) addDir(path string, fi os.FileInfo) {
+	name := filepath.ToSlash(fss.cleanPrefix(path))
+	if len(name) == 0 || name == "." || name == "/" {
+		return
+	}
+	name = strings.TrimSuffix(name, "/")
+
+	_, ok := fss.dirs[name]
+	if ok {
+		return
+	}
+
+	fss.dirs[name] = &asset{
+		path: path,
+		name: name,
+		fi:   fi,
+	}
+}
+
 // getListFileInfo will return list of files in `path`.
 //
 // (1) set the path visited status to true,
@@ -176,6 +201,8 @@ func (fss *fsScanner) scanSymlink(path string, recursive bool) (
 		return nil
 	}
 
+	fss.addDir(path, fi)
+
 	list, err := fss.getListFileInfo(path)
 	if err != nil {
 		return err
@@ -231,6 +258,8 @@ func (fss *fsScanner) Scan(path, realPath string, recursive bool) (err error) {
 		return nil
 	}
 
+	fss.addDir(path, fi)
+
 	list, err := fss.getListFileInfo(path)
 	if err != nil {
 		return err
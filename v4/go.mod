@@ -1,3 +1,3 @@
 module github.com/shuLhan/go-bindata/v4
 
-go 1.13
+go 1.16
@@ -0,0 +1,337 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// handlerImports contains list of packages required by the generated
+// http.Handler.
+// nolint: gochecknoglobals
+var handlerImports = []string{
+	"io", "mime", "net/http", "os", "path", "strconv", "strings",
+}
+
+// addHandlerImports add the packages required by the generated
+// http.Handler.
+func addHandlerImports(c *Config, imports goImports) {
+	imports.add(handlerImports...)
+	if c.Cache || c.NoMetadata {
+		imports.add("bytes")
+	} else {
+		imports.add("errors", "io/ioutil")
+	}
+}
+
+// writeHandler writes the function AssetHandler in the scope s, which send
+// the compressed data as is using the content encoding of compressor.
+//
+// If the client does not accept the content encoding, the asset is read
+// from the cache, if its set, or decompressed while its being send.
+// Since the size of decompressed asset is required to handle the range
+// request, the asset is read into memory if NoMetadata is set.
+func writeHandler(w io.Writer, c *Config, s genScope) (err error) {
+	err = writeScoped(w, s, tmplHandler)
+	if err != nil {
+		return err
+	}
+	err = writeHandlerCommon(w, c)
+	if err != nil {
+		return err
+	}
+	if c.Cache || c.NoMetadata {
+		err = writeScoped(w, s, tmplHandlerSniff+tmplHandlerIdentityMemory)
+	} else {
+		err = writeScoped(w, s, tmplHandlerSniff+tmplHandlerIdentityStream+
+			tmplHandlerSeeker)
+	}
+	return err
+}
+
+// writeHandlerCommon writes the types and functions of handler that does
+// not depends on the assets.
+func writeHandlerCommon(w io.Writer, c *Config) (err error) {
+	var encoding string
+	if c.compressor != nil {
+		encoding = c.compressor.ContentEncoding()
+	}
+	_, err = fmt.Fprintf(w, tmplHandlerCommon, encoding)
+	return err
+}
+
+const tmplHandler = `
+//
+// AssetHandler returns an http.Handler that serve the embedded assets, using
+// the request URL path without the leading "/" as the asset name.
+//
+// If the asset is stored compressed and the client accept its content
+// encoding, the compressed data is send as is, without decompressing it.
+// The ETag header is set from the asset MD5 checksum, if its available, or
+// from the asset modification time and size, and the Last-Modified header is
+// set from the asset modification time.
+// Conditional and range requests are handled by http.ServeContent, except
+// that range is not supported on encoded response.
+//
+{{.Decl "AssetHandler"}}() http.Handler {
+{{- if .Bundle}}
+	return bindataHandler{b: b}
+}
+
+type bindataHandler struct {
+	b *Bundle
+}
+{{- else}}
+	return bindataHandler{}
+}
+
+type bindataHandler struct{}
+{{- end}}
+
+func (h bindataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+
+	raw, info, compressed, err := {{.Call "h" "rawOpen"}}(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+
+	etag := bindataETag(info)
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ex, ok := info.(interface{ ContentType() string }); ok {
+		ctype = ex.ContentType()
+	}
+
+	if !compressed {
+		if len(etag) > 0 {
+			header.Set("ETag", "\""+etag+"\"")
+		}
+		if len(ctype) > 0 {
+			header.Set("Content-Type", ctype)
+		}
+		http.ServeContent(w, r, name, info.ModTime(), raw)
+		return
+	}
+
+	header.Add("Vary", "Accept-Encoding")
+
+	if len(ctype) == 0 {
+		ctype, err = {{.Call "h" "sniff"}}(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	header.Set("Content-Type", ctype)
+
+	if !bindataAcceptEncoding(r) {
+		content, err := {{.Call "h" "identity"}}(name, info)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer content.Close()
+		if len(etag) > 0 {
+			header.Set("ETag", "\""+etag+"\"")
+		}
+		http.ServeContent(w, r, name, info.ModTime(), content)
+		return
+	}
+
+	if len(etag) > 0 {
+		header.Set("ETag", "\""+etag+"-"+bindataContentEncoding+"\"")
+	}
+	header.Set("Content-Encoding", bindataContentEncoding)
+
+	// Range on the encoded content is not supported.
+	r = r.Clone(r.Context())
+	r.Header.Del("Range")
+	r.Header.Del("If-Range")
+
+	http.ServeContent(w, r, name, info.ModTime(), raw)
+}
+`
+
+const tmplHandlerCommon = `
+// bindataReadSeekCloser is the content of asset that is send by the
+// handler.
+type bindataReadSeekCloser interface {
+	io.ReadSeeker
+	io.Closer
+}
+
+// bindataETag return the entity tag of asset from its MD5 checksum, or from
+// its modification time and size if the checksum is not available.
+// It returns empty string if the asset does not have the modification time.
+func bindataETag(info os.FileInfo) string {
+	if ex, ok := info.(interface{ MD5Checksum() string }); ok && len(ex.MD5Checksum()) > 0 {
+		return ex.MD5Checksum()
+	}
+	modTime := info.ModTime().Unix()
+	if modTime <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(modTime, 16) + "-" + strconv.FormatInt(info.Size(), 16)
+}
+
+// bindataContentEncoding is the HTTP content encoding of compressed asset,
+// or empty if the compressed asset can not be send as is.
+const bindataContentEncoding = %q
+
+// bindataAcceptEncoding return true if the request Accept-Encoding header
+// contains bindataContentEncoding with non-zero quality value.
+func bindataAcceptEncoding(r *http.Request) bool {
+	if len(bindataContentEncoding) == 0 {
+		return false
+	}
+	for _, v := range r.Header["Accept-Encoding"] {
+		for _, enc := range strings.Split(v, ",") {
+			params := strings.Split(enc, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), bindataContentEncoding) {
+				continue
+			}
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if !strings.HasPrefix(param, "q=") {
+					continue
+				}
+				q, err := strconv.ParseFloat(param[2:], 64)
+				if err == nil && q == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+`
+
+const tmplHandlerSniff = `
+// {{.Name "sniff"}} detect the content type of asset name from its first 512
+// bytes.
+{{.Decl "sniff"}}(name string) (string, error) {
+	rc, err := {{.Ref "AssetReader"}}(name)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(rc, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+`
+
+// tmplHandlerIdentityMemory return the decompressed content of asset from
+// Asset, which is cached if Cache is set.
+const tmplHandlerIdentityMemory = `
+// {{.Name "identity"}} return the decompressed content of asset name.
+{{.Decl "identity"}}(name string, info os.FileInfo) (bindataReadSeekCloser, error) {
+	data, err := {{.Ref "Asset"}}(name)
+	if err != nil {
+		return nil, err
+	}
+	return bindataNopCloser{bytes.NewReader(data)}, nil
+}
+`
+
+// tmplHandlerIdentityStream decompress the asset while its being send.
+const tmplHandlerIdentityStream = `
+// {{.Name "identity"}} return the decompressed content of asset name, which
+// is decompressed while its being read.
+{{.Decl "identity"}}(name string, info os.FileInfo) (bindataReadSeekCloser, error) {
+	return &bindataSeeker{ {{- if .Bundle}}b: b, {{end}}name: name, size: info.Size()}, nil
+}
+
+// bindataSeeker implements io.ReadSeeker on the decompressed content of
+// asset, using the asset size to seek from the end.
+// Seeking does not read the content, while reading after seeking backward
+// decompress the asset again from the beginning.
+type bindataSeeker struct {
+{{- if .Bundle}}
+	b    *Bundle
+{{- end}}
+	name string
+	size int64
+	off  int64
+	pos  int64
+	rc   io.ReadCloser
+}
+
+func (s *bindataSeeker) Read(p []byte) (int, error) {
+	if s.off >= s.size {
+		return 0, io.EOF
+	}
+	if s.rc == nil || s.pos > s.off {
+		if s.rc != nil {
+			_ = s.rc.Close()
+		}
+		rc, err := {{.Call "s" "AssetReader"}}(s.name)
+		if err != nil {
+			return 0, err
+		}
+		s.rc = rc
+		s.pos = 0
+	}
+	if s.pos < s.off {
+		n, err := io.CopyN(ioutil.Discard, s.rc, s.off-s.pos)
+		s.pos += n
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := s.rc.Read(p)
+	s.pos += int64(n)
+	s.off = s.pos
+	return n, err
+}
+`
+
+// tmplHandlerSeeker contains the methods of bindataSeeker that does not read
+// the asset.
+const tmplHandlerSeeker = `
+func (s *bindataSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.off
+	case io.SeekEnd:
+		offset += s.size
+	default:
+		return 0, errors.New("Seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("Seek: negative position")
+	}
+	s.off = offset
+	return offset, nil
+}
+
+func (s *bindataSeeker) Close() error {
+	if s.rc == nil {
+		return nil
+	}
+	return s.rc.Close()
+}
+`
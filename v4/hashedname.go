@@ -0,0 +1,273 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// hashedNameLen is the number of hex characters of content hash that is
+// inserted into the hashed name of asset.
+const hashedNameLen = 8
+
+// hashedName return the name of asset with the hash inserted before its
+// extension, for example "js/app.3f2a9c1b.js" for "js/app.js".
+// If the base name does not have extension, or its a dot file, the hash is
+// appended to the name.
+func hashedName(name, sum string) string {
+	base := path.Base(name)
+	ext := path.Ext(base)
+	if len(ext) == 0 || len(ext) == len(base) {
+		return name + "." + sum
+	}
+	return strings.TrimSuffix(name, ext) + "." + sum + ext
+}
+
+// hashAssets compute the SHA-256 of each asset content, and its hashed name
+// from the first hashedNameLen characters of the hex encoded hash, if
+// HashedNames is set.
+// If RewriteHashedNames is set, the references inside CSS and HTML assets
+// are rewritten first, so their hash is computed from the rewritten content.
+//
+// The hashes are computed before any code is written, so they are available
+// to the common file in split mode.
+func hashAssets(c *Config, keys []string, toc map[string]*asset) (err error) {
+	hn := newHashNamer(c, keys, toc)
+	for _, key := range keys {
+		_, err = hn.sum(toc[key])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashNamer compute the hash of assets, following the references between
+// them if the references are rewritten.
+type hashNamer struct {
+	c *Config
+
+	// names contains all assets, mapped by its name.
+	names map[string]*asset
+
+	// visiting contains the assets whose references are being
+	// rewritten, to detect the circular references.
+	visiting map[*asset]bool
+
+	// warn is the writer where the warnings are printed, which is the
+	// standard error if Verbose is set.
+	warn io.Writer
+}
+
+func newHashNamer(c *Config, keys []string, toc map[string]*asset) (hn *hashNamer) {
+	hn = &hashNamer{
+		c:        c,
+		names:    make(map[string]*asset, len(keys)),
+		visiting: make(map[*asset]bool),
+		warn:     ioutil.Discard,
+	}
+	if c.Verbose {
+		hn.warn = os.Stderr
+	}
+	for _, key := range keys {
+		ast := toc[key]
+		hn.names[ast.name] = ast
+	}
+	return hn
+}
+
+// sum return the hex encoded SHA-256 of asset content, and set its hashed
+// name.
+func (hn *hashNamer) sum(ast *asset) (sum string, err error) {
+	if len(ast.sum) > 0 {
+		return ast.sum, nil
+	}
+
+	h := sha256.New()
+
+	if hn.c.RewriteHashedNames && isRewritable(ast.name) {
+		hn.visiting[ast] = true
+		err = hn.rewrite(ast)
+		delete(hn.visiting, ast)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if ast.content != nil {
+		_, _ = h.Write(ast.content)
+	} else {
+		err = hashFile(h, ast.path)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ast.sum = fmt.Sprintf("%x", h.Sum(nil))
+	if hn.c.HashedNames {
+		ast.hashedName = hashedName(ast.name, ast.sum[:hashedNameLen])
+	}
+
+	return ast.sum, nil
+}
+
+// hashFile write the content of file into h.
+func hashFile(h hash.Hash, file string) (err error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(h, fd)
+	if err != nil {
+		_ = fd.Close()
+		return err
+	}
+
+	return fd.Close()
+}
+
+// writeHashedNamesManifest writes the JSON object that map the original
+// name of each asset to its hashed name into file.
+func writeHashedNamesManifest(file string, keys []string, toc map[string]*asset) (err error) {
+	manifest := make(map[string]string, len(keys))
+	for _, key := range keys {
+		ast := toc[key]
+		manifest[ast.name] = ast.hashedName
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	return ioutil.WriteFile(file, b, 0666)
+}
+
+// writeHashedNames writes the functions AssetHashedName and
+// AssetOriginalName in the scope s.
+func writeHashedNames(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplHashedNames)
+}
+
+// writeHashedNamesTables writes the tables that map the original name of
+// asset to its hashed name and vice versa, with the bundle name as the
+// suffix of table name.
+func writeHashedNamesTables(w io.Writer, bundle string, keys []string, toc map[string]*asset) (err error) {
+	_, err = fmt.Fprintf(w, tmplHashedNamesTable, bundle, bundle)
+	if err != nil {
+		return err
+	}
+
+	longestNameLen := getLongestAssetNameLen(keys)
+	for _, key := range keys {
+		ast := toc[key]
+		_, err = fmt.Fprintf(w, "\t%q:%*s%q,\n", ast.name,
+			longestNameLen-len(ast.name)+1, "", ast.hashedName)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, tmplOriginalNames, bundle, bundle)
+	if err != nil {
+		return err
+	}
+
+	longestNameLen += len(".") + hashedNameLen
+	for _, key := range keys {
+		ast := toc[key]
+		_, err = fmt.Fprintf(w, "\t%q:%*s%q,\n", ast.hashedName,
+			longestNameLen-len(ast.hashedName)+1, "", ast.name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+// tmplHashedNameAsset is the part of function Asset that read the asset
+// by its hashed name, if its not found by its original name.
+const tmplHashedNameAsset = `	if orig, ok := {{.Field "original"}}[cannonicalName]; ok {
+		return {{.Ref "Asset"}}(orig)
+	}
+`
+
+// tmplHashedNameAssetInfo is the part of function AssetInfo that read the
+// asset information by its hashed name, if its not found by its original
+// name.
+const tmplHashedNameAssetInfo = `	if orig, ok := {{.Field "original"}}[cannonicalName]; ok {
+		return {{.Ref "AssetInfo"}}(orig)
+	}
+`
+
+// tmplHashedNameRawOpen is the part of function bindataRawOpen that resolve
+// the hashed name into the original name.
+const tmplHashedNameRawOpen = `
+	if orig, ok := {{.Field "original"}}[cannonicalName]; ok {
+		cannonicalName = orig
+	}`
+
+// tmplHashedNameExist is the part of method exist of Bundle that lookup the
+// hashed name.
+const tmplHashedNameExist = `
+	if _, ok := {{.Field "original"}}[name]; ok {
+		return true
+	}`
+
+const tmplHashedNames = `
+//
+// AssetHashedName return the name of asset with the hash of its content
+// inserted before the extension, for example "app.3f2a9c1b.js" for "app.js".
+// The asset can be read by its hashed name too, so the hashed name can be
+// used in URL that is cached forever by the browser.
+//
+{{.Decl "AssetHashedName"}}(name string) (string, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if hashed, ok := {{.Field "hashed"}}[cannonicalName]; ok {
+		return hashed, nil
+	}
+	return "", &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+}
+
+//
+// AssetOriginalName return the original name of asset from its hashed name.
+//
+{{.Decl "AssetOriginalName"}}(hashed string) (string, error) {
+	cannonicalName := strings.Replace(hashed, "\\", "/", -1)
+	if orig, ok := {{.Field "original"}}[cannonicalName]; ok {
+		return orig, nil
+	}
+	return "", &os.PathError{Op: "open", Path: hashed, Err: os.ErrNotExist}
+}
+`
+
+const tmplHashedNamesTable = `
+//
+// _bindataHashedNames%s is a table, mapping the original name of each asset to
+// its hashed name.
+//
+var _bindataHashedNames%s = map[string]string{
+`
+
+const tmplOriginalNames = `}
+
+//
+// _bindataOriginalNames%s is a table, mapping the hashed name of each asset to
+// its original name.
+//
+var _bindataOriginalNames%s = map[string]string{
+`
@@ -0,0 +1,34 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"testing"
+)
+
+func TestHashedName(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+	}{{
+		name: "app.js",
+		exp:  "app.3f2a9c1b.js",
+	}, {
+		name: "js/app.min.js",
+		exp:  "js/app.min.3f2a9c1b.js",
+	}, {
+		name: "v1.0/LICENSE",
+		exp:  "v1.0/LICENSE.3f2a9c1b",
+	}, {
+		name: "conf/.htaccess",
+		exp:  "conf/.htaccess.3f2a9c1b",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		assert(t, test.exp, hashedName(test.name, "3f2a9c1b"), true)
+	}
+}
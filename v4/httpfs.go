@@ -0,0 +1,146 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// httpFSImports contains list of packages required by the generated
+// http.FileSystem implementation.
+// nolint: gochecknoglobals
+var httpFSImports = []string{
+	"bytes", "errors", "io", "net/http", "os", "path",
+}
+
+// writeHTTPFileSystem writes the function AssetHTTPFileSystem in the scope
+// s.
+func writeHTTPFileSystem(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplHTTPFileSystem)
+}
+
+const tmplHTTPFileSystem = `
+//
+// AssetHTTPFileSystem returns the embedded assets as http.FileSystem, so it
+// can be served directly by http.FileServer.
+//
+{{.Decl "AssetHTTPFileSystem"}}() http.FileSystem {
+{{- if .Bundle}}
+	return bindataHTTPFS{b: b}
+}
+
+type bindataHTTPFS struct {
+	b *Bundle
+}
+{{- else}}
+	return bindataHTTPFS{}
+}
+
+type bindataHTTPFS struct{}
+{{- end}}
+
+// Open opens the named file or directory.
+// The name is rooted by "/", for example "/data/foo.txt".
+func (hfs bindataHTTPFS) Open(name string) (http.File, error) {
+	name = path.Clean("/" + name)[1:]
+
+	node := {{.Call "hfs" "node"}}(name)
+	if node == nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+
+	fi, err := {{.Call "hfs" "nodeInfo"}}(name, node)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+	if node.Func == nil {
+		return &bindataHTTPDir{ {{- if .Bundle}}b: hfs.b, {{end}}name: name, node: node, info: fi}, nil
+	}
+
+	a, err := node.Func()
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+	f := &bindataHTTPFile{
+		Reader: bytes.NewReader(a.bytes),
+		info:   fi,
+	}
+	return f, nil
+}
+` + tmplHTTPFile + `
+// bindataHTTPDir implements http.File for a node in the assets tree.
+type bindataHTTPDir struct {
+{{- if .Bundle}}
+	b       *Bundle
+{{- end}}
+	name    string
+	node    *bintree
+	info    os.FileInfo
+	entries []os.FileInfo
+	offset  int
+}
+
+func (d *bindataHTTPDir) Close() error {
+	return nil
+}
+func (d *bindataHTTPDir) Read([]byte) (int, error) {
+	return 0, &os.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// Seek only allow rewinding the directory entries to the beginning.
+func (d *bindataHTTPDir) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		d.offset = 0
+		return 0, nil
+	}
+	return 0, &os.PathError{Op: "seek", Path: d.name, Err: os.ErrInvalid}
+}
+
+// Readdir returns the next count entries of directory, sorted by filename.
+func (d *bindataHTTPDir) Readdir(count int) ([]os.FileInfo, error) {
+	if d.entries == nil {
+		list, err := {{.Call "d" "nodeEntries"}}(d.name, d.node)
+		if err != nil {
+			return nil, &os.PathError{Op: "readdir", Path: d.name, Err: err}
+		}
+		d.entries = list
+	}
+
+	rest := d.entries[d.offset:]
+	if count <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(rest) {
+		count = len(rest)
+	}
+	d.offset += count
+	return rest[:count], nil
+}
+func (d *bindataHTTPDir) Stat() (os.FileInfo, error) {
+	return d.info, nil
+}
+`
+
+const tmplHTTPFile = `
+// bindataHTTPFile implements http.File for an asset.
+type bindataHTTPFile struct {
+	*bytes.Reader
+	info os.FileInfo
+}
+
+func (f *bindataHTTPFile) Close() error {
+	return nil
+}
+func (f *bindataHTTPFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, &os.PathError{Op: "readdir", Path: f.info.Name(), Err: errors.New("not a directory")}
+}
+func (f *bindataHTTPFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+`
@@ -0,0 +1,89 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// goImports contains the set of packages imported by the generated code.
+type goImports map[string]struct{}
+
+func newGoImports(pkgs ...string) (imports goImports) {
+	imports = make(goImports, len(pkgs))
+	imports.add(pkgs...)
+	return imports
+}
+
+// add one or more packages into the set.
+func (imports goImports) add(pkgs ...string) {
+	for _, pkg := range pkgs {
+		imports[pkg] = struct{}{}
+	}
+}
+
+// write the import declaration, sorted by package path, to w.
+func (imports goImports) write(w io.Writer) (err error) {
+	pkgs := make([]string, 0, len(imports))
+	for pkg := range imports {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	_, err = io.WriteString(w, "import (\n")
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		_, err = fmt.Fprintf(w, "\t%q\n", pkg)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, ")\n")
+
+	return err
+}
+
+// addFeatureImports add the packages required by the optional parts of the
+// generated API.
+func addFeatureImports(c *Config, imports goImports) {
+	if c.FS {
+		imports.add(fsImports...)
+	}
+	if c.HTTPFileSystem {
+		imports.add(httpFSImports...)
+	}
+	if c.HTTPHandler {
+		addHandlerImports(c, imports)
+	}
+	if c.Archive {
+		imports.add(archiveImports...)
+	}
+	if c.RestoreWithOptions {
+		imports.add(restoreWithOptionsImports...)
+	}
+	if c.Cache {
+		imports.add(cacheImports...)
+	}
+	if c.Overlay {
+		imports.add(overlayImports...)
+		imports.add(overlayChecksumImports(c)...)
+	}
+	if c.ContentType {
+		imports.add(contentTypeImports...)
+	}
+	if c.Verify {
+		addVerifyImports(c, imports)
+	}
+	if c.SignKey != nil {
+		addSignImports(c, imports)
+	}
+	if len(c.EncryptKey) > 0 {
+		imports.add(encryptImports...)
+	}
+}
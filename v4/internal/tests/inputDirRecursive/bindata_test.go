@@ -7,6 +7,9 @@ package bindata
 import (
 	"bytes"
 	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
 	"testing"
 )
 
@@ -79,6 +82,136 @@ func TestAsset(t *testing.T) {
 	}
 }
 
+func TestAssetNames(t *testing.T) {
+	exp := []string{
+		"in/a/test.asset",
+		"in/b/test.asset",
+		"in/c/test.asset",
+		"in/file name",
+		"in/test.asset",
+	}
+	assert(t, exp, AssetNames(), true)
+
+	got, err := AssetDir("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"a", "b", "c", "file name", "test.asset"}, got, true)
+}
+
+func TestAssetWalk(t *testing.T) {
+	tests := []struct {
+		desc   string
+		root   string
+		skip   string
+		exp    []string
+		expErr string
+	}{{
+		desc: "With empty root",
+		exp: []string{
+			"in", "in/a", "in/a/test.asset", "in/b",
+			"in/b/test.asset", "in/c", "in/c/test.asset",
+			"in/file name", "in/test.asset",
+		},
+	}, {
+		desc: "With directory",
+		root: "in/b",
+		exp:  []string{"in/b", "in/b/test.asset"},
+	}, {
+		desc: "With file",
+		root: "in/test.asset",
+		exp:  []string{"in/test.asset"},
+	}, {
+		desc: "With skipping directory",
+		root: "in",
+		skip: "in/b",
+		exp: []string{
+			"in", "in/a", "in/a/test.asset", "in/b", "in/c",
+			"in/c/test.asset", "in/file name", "in/test.asset",
+		},
+	}, {
+		desc: "With skipping file",
+		root: "in",
+		skip: "in/file name",
+		exp: []string{
+			"in", "in/a", "in/a/test.asset", "in/b",
+			"in/b/test.asset", "in/c", "in/c/test.asset",
+			"in/file name",
+		},
+	}, {
+		desc:   "With not exist root",
+		root:   "in/d",
+		exp:    []string{},
+		expErr: "open in/d: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		got := []string{}
+		err := AssetWalk(test.root, func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			assert(t, path.Base(name), info.Name(), true)
+			got = append(got, name)
+			if name == test.skip {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestAssetGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		exp     []string
+		expErr  error
+	}{{
+		pattern: "in/*",
+		exp:     []string{"in/file name", "in/test.asset"},
+	}, {
+		pattern: "in/*/test.asset",
+		exp:     []string{"in/a/test.asset", "in/b/test.asset", "in/c/test.asset"},
+	}, {
+		pattern: "in/[ab]/*",
+		exp:     []string{"in/a/test.asset", "in/b/test.asset"},
+	}, {
+		pattern: "in/d/*",
+		exp:     []string{},
+	}, {
+		pattern: "in/[",
+		expErr:  path.ErrBadPattern,
+	}}
+
+	for _, test := range tests {
+		t.Log(test.pattern)
+
+		got, err := AssetGlob(test.pattern)
+		if err != nil {
+			assert(t, test.expErr, err, true)
+			continue
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestAssetNamesWithPrefix(t *testing.T) {
+	got := AssetNamesWithPrefix("in/")
+	assert(t, AssetNames(), got, true)
+
+	got = AssetNamesWithPrefix("in/t")
+	assert(t, []string{"in/test.asset"}, got, true)
+
+	got = AssetNamesWithPrefix("out/")
+	assert(t, []string{}, got, true)
+}
+
 func TestGeneratedContent(t *testing.T) {
 	expFile := "bindata.exp"
 	gotFile := "bindata.go"
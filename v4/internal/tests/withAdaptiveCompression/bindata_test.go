@@ -0,0 +1,87 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestAdaptiveCompression(t *testing.T) {
+	tests := []struct {
+		name          string
+		expCompressed bool
+	}{{
+		name: "adaptive/font.bin",
+	}, {
+		name:          "adaptive/index.html",
+		expCompressed: true,
+	}, {
+		name: "adaptive/logo.png",
+	}, {
+		name: "adaptive/random.dat",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		exp, err := ioutil.ReadFile("../../../testdata/" + test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Asset(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, exp, got, true)
+
+		_, _, compressed, err := bindataRawOpen(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, test.expCompressed, compressed, true)
+
+		rc, err := AssetReader(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, isSeeker := rc.(io.Seeker)
+		assert(t, !test.expCompressed, isSeeker, true)
+
+		got, err = ioutil.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, exp, got, true)
+	}
+
+	errs := VerifyAssets(context.Background())
+	assert(t, []error(nil), errs, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+type archiveEntry struct {
+	name    string
+	mode    os.FileMode
+	modTime int64
+	content string
+}
+
+// nolint: gochecknoglobals
+var expArchiveEntries = []archiveEntry{
+	{"in/", os.ModeDir | 0755, 1586263518, ""},
+	{"in/a/", os.ModeDir | 0755, 1586263518, ""},
+	{"in/a/test.asset", 0644, 1586263518, "// sample file\n"},
+	{"in/b/", os.ModeDir | 0755, 1586263518, ""},
+	{"in/b/test.asset", 0644, 1586263518, "// sample file\n"},
+	{"in/c/", os.ModeDir | 0755, 1586263518, ""},
+	{"in/c/test.asset", 0644, 1586263518, "// sample file\n"},
+	{"in/file name", 0644, 1586263518, "// Content of \"testdata/in/file name\"\n"},
+	{"in/test.asset", 0644, 1586263518, "// sample file\n"},
+}
+
+func TestWriteTar(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTar(&buf, "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []archiveEntry
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, archiveEntry{
+			name:    hdr.Name,
+			mode:    hdr.FileInfo().Mode(),
+			modTime: hdr.ModTime.Unix(),
+			content: string(content),
+		})
+	}
+
+	assert(t, expArchiveEntries, got, true)
+}
+
+func TestWriteZip(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteZip(&buf, "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []archiveEntry
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = rc.Close()
+
+		got = append(got, archiveEntry{
+			name:    f.Name,
+			mode:    f.Mode(),
+			modTime: f.Modified.Unix(),
+			content: string(content),
+		})
+	}
+
+	assert(t, expArchiveEntries, got, true)
+}
+
+func TestWriteTar_notExist(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTar(&buf, "in/d")
+	assert(t, "open in/d: file does not exist", err.Error(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
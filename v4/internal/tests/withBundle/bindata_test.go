@@ -0,0 +1,166 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBundle_Asset(t *testing.T) {
+	tests := []struct {
+		desc   string
+		bundle *Bundle
+		name   string
+		exp    string
+		expErr string
+	}{{
+		desc:   "With WebAssets",
+		bundle: WebAssets,
+		name:   "in/b/test.asset",
+		exp:    "// sample file\n",
+	}, {
+		desc:   "With DocAssets",
+		bundle: DocAssets,
+		name:   "in/a/test.asset",
+		exp:    "// sample file\n",
+	}, {
+		desc:   "With asset not in DocAssets",
+		bundle: DocAssets,
+		name:   "in/b/test.asset",
+		expErr: "open in/b/test.asset: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		got, err := test.bundle.Asset(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, string(got), true)
+
+		rc, err := test.bundle.AssetReader(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = rc.Close()
+		assert(t, test.exp, string(got), true)
+	}
+}
+
+func TestBundle_AssetNames(t *testing.T) {
+	exp := []string{
+		"in/a/test.asset",
+		"in/b/test.asset",
+		"in/c/test.asset",
+		"in/file name",
+		"in/test.asset",
+	}
+	assert(t, exp, WebAssets.AssetNames(), true)
+	assert(t, exp, AssetNames(), true)
+
+	assert(t, []string{"in/a/test.asset"}, DocAssets.AssetNames(), true)
+}
+
+func TestBundle_AssetDir(t *testing.T) {
+	got, err := WebAssets.AssetDir("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"a", "b", "c", "file name", "test.asset"}, got, true)
+
+	got, err = DocAssets.AssetDir("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"a"}, got, true)
+
+	_, err = DocAssets.AssetDir("in/a/test.asset")
+	assert(t, "open in/a/test.asset: file does not exist", err.Error(), true)
+}
+
+func TestBundle_AssetInfo(t *testing.T) {
+	fi, err := DocAssets.AssetInfo("in/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, true, fi.IsDir(), true)
+	assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+
+	fi, err = AssetInfo("in/file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/file name", fi.Name(), true)
+	assert(t, int64(38), fi.Size(), true)
+}
+
+func TestBundle_AssetWalk(t *testing.T) {
+	var got []string
+	err := DocAssets.AssetWalk("", func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		got = append(got, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"in", "in/a", "in/a/test.asset"}, got, true)
+}
+
+func TestBundle_RestoreAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = DocAssets.RestoreAssets(dir, "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(filepath.Join(dir, "in", "a", "test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+
+	_, err = os.Stat(filepath.Join(dir, "in", "b"))
+	assert(t, true, os.IsNotExist(err), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	for _, name := range []string{"bindata", "bindata_doc"} {
+		expFile := name + ".exp"
+		gotFile := name + ".go"
+
+		// Compare the generate file with expected.
+		exp, err := ioutil.ReadFile(expFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ioutil.ReadFile(gotFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(exp, got) {
+			t.Fatalf("%s not match with %s", expFile, gotFile)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func readKey(t *testing.T) []byte {
+	b, err := ioutil.ReadFile("../../../testdata/encrypt/key.hex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func resetKey() {
+	_bindataKeyWebAssets.aead = nil
+	_bindataKeyWebAssets.provider = nil
+}
+
+func TestBundle_AssetWithoutKey(t *testing.T) {
+	defer resetKey()
+
+	var errDecrypt *AssetDecryptError
+
+	_, err := WebAssets.Asset("in/test.asset")
+	assert(t, true, errors.As(err, &errDecrypt), true)
+	assert(t, "in/test.asset", errDecrypt.Name, true)
+	assert(t, true, errors.Is(err, ErrAssetKeyNotSet), true)
+
+	_, err = WebAssets.AssetInfo("in/test.asset")
+	assert(t, true, errors.As(err, &errDecrypt), true)
+	assert(t, true, errors.Is(err, ErrAssetKeyNotSet), true)
+
+	err = WebAssets.SetAssetKey(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = WebAssets.Asset("in/test.asset")
+	assert(t, true, errors.As(err, &errDecrypt), true)
+	assert(t, false, errors.Is(err, ErrAssetKeyNotSet), true)
+}
+
+func TestBundle_SetAssetKey(t *testing.T) {
+	defer resetKey()
+
+	err := WebAssets.SetAssetKey(readKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := WebAssets.Asset("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+}
+
+// TestBundle_SetAssetKeyProvider test that the provider can set the key
+// itself, since it is called without holding the lock of key.
+func TestBundle_SetAssetKeyProvider(t *testing.T) {
+	defer resetKey()
+
+	WebAssets.SetAssetKeyProvider(func() ([]byte, error) {
+		key := readKey(t)
+		return key, WebAssets.SetAssetKey(key)
+	})
+
+	got, err := WebAssets.Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate file with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+//go:generate go run main.go
+
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"regexp"
+
+	"github.com/shuLhan/go-bindata/v4"
+)
+
+func main() {
+	b, err := ioutil.ReadFile("../../../testdata/encrypt/key.hex")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key, err := bindata.ParseEncryptKey(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg := &bindata.Config{
+		Package:     "bindata",
+		AssetPrefix: bindata.DefAssetPrefixName,
+		Prefix:      regexp.MustCompile(".*/testdata/"),
+		ModTime:     1586263518,
+		Ignore: []*regexp.Regexp{
+			regexp.MustCompile("split/"),
+		},
+		Input: []bindata.InputConfig{
+			bindata.CreateInputConfig("../../../testdata/in/..."),
+		},
+		EncryptKey: key,
+		Bundle:     "WebAssets",
+	}
+
+	err = bindata.Translate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBundle_Overlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, "in", "a"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "in", "a", "test.asset"),
+		[]byte("// patched file\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The overlay directory is set only on WebAssets, using the
+	// package-level shortcut.
+	SetOverlayDir(dir)
+	defer SetOverlayDir("")
+
+	assert(t, dir, WebAssets.OverlayDir(), true)
+	assert(t, "", DocAssets.OverlayDir(), true)
+
+	got, err := WebAssets.Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// patched file\n", string(got), true)
+
+	got, err = DocAssets.Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+}
+
+func TestBundle_AssetCache(t *testing.T) {
+	WebAssets.AssetCache().Purge()
+	DocAssets.AssetCache().Purge()
+
+	_, err := DocAssets.Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert(t, int64(0), AssetCache.Size(), true)
+	assert(t, int64(15), DocAssets.AssetCache().Size(), true)
+
+	err = AssetCache.Preload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(15), DocAssets.AssetCache().Size(), true)
+	assert(t, int64(98), WebAssets.AssetCache().Size(), true)
+}
+
+func TestBundle_AssetHashedName(t *testing.T) {
+	hashed, err := DocAssets.AssetHashedName("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/a/test.67e51a66.asset", hashed, true)
+
+	orig, err := DocAssets.AssetOriginalName(hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/a/test.asset", orig, true)
+
+	got, err := DocAssets.Asset(hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+
+	_, err = DocAssets.AssetHashedName("in/b/test.asset")
+	assert(t, "open in/b/test.asset: file does not exist", err.Error(), true)
+}
+
+func TestBundle_AssetContentType(t *testing.T) {
+	got, err := DocAssets.AssetContentType("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "text/plain; charset=utf-8", got, true)
+}
+
+func TestBundle_VerifyAssets(t *testing.T) {
+	assert(t, 0, len(VerifyAssets(context.Background())), true)
+	assert(t, 0, len(DocAssets.VerifyAssets(context.Background())), true)
+}
+
+func TestBundle_AssetHandler(t *testing.T) {
+	tests := []struct {
+		desc    string
+		handler http.Handler
+		path    string
+		expCode int
+		expBody string
+	}{{
+		desc:    "With DocAssets",
+		handler: DocAssets.AssetHandler(),
+		path:    "/in/a/test.asset",
+		expCode: http.StatusOK,
+		expBody: "// sample file\n",
+	}, {
+		desc:    "With asset not in DocAssets",
+		handler: DocAssets.AssetHandler(),
+		path:    "/in/b/test.asset",
+		expCode: http.StatusNotFound,
+	}, {
+		desc:    "With WebAssets",
+		handler: AssetHandler(),
+		path:    "/in/b/test.asset",
+		expCode: http.StatusOK,
+		expBody: "// sample file\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		res := httptest.NewRecorder()
+
+		test.handler.ServeHTTP(res, req)
+
+		assert(t, test.expCode, res.Code, true)
+		if test.expCode == http.StatusOK {
+			assert(t, test.expBody, res.Body.String(), true)
+		}
+	}
+}
+
+func TestBundle_WriteZip(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := DocAssets.WriteZip(&buf, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert(t, []string{"in/", "in/a/", "in/a/test.asset"}, names, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	for _, name := range []string{"bindata", "bindata_doc"} {
+		expFile := name + ".exp"
+		gotFile := name + ".go"
+
+		// Compare the generate file with expected.
+		exp, err := ioutil.ReadFile(expFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ioutil.ReadFile(gotFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(exp, got) {
+			t.Fatalf("%s not match with %s", expFile, gotFile)
+		}
+	}
+}
+
+// TestBundleLayers test that the layer of name is decided using the hashed
+// names and the overlay directory of bundle, not only its embedded tree.
+func TestBundleLayers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, "in", "b"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "in", "b", "test.asset"),
+		[]byte("// patched file\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "in", "new.txt"),
+		[]byte("new\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	DocAssets.SetOverlayDir(dir)
+	defer DocAssets.SetOverlayDir("")
+
+	layers := BundleLayers{DocAssets, WebAssets}
+
+	tests := []struct {
+		desc     string
+		name     string
+		expLayer *Bundle
+		exp      string
+	}{{
+		desc:     "With hashed name",
+		name:     "in/a/test.67e51a66.asset",
+		expLayer: DocAssets,
+		exp:      "// sample file\n",
+	}, {
+		desc:     "With file only in overlay directory",
+		name:     "in/new.txt",
+		expLayer: DocAssets,
+		exp:      "new\n",
+	}, {
+		desc:     "With overlay file that override the lower bundle",
+		name:     "in/b/test.asset",
+		expLayer: DocAssets,
+		exp:      "// patched file\n",
+	}, {
+		desc:     "With asset in lower bundle",
+		name:     "in/c/test.asset",
+		expLayer: WebAssets,
+		exp:      "// sample file\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		assert(t, true, layers.Layer(test.name) == test.expLayer, true)
+
+		got, err := layers.Asset(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, test.exp, string(got), true)
+
+		fi, err := layers.AssetInfo(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, int64(len(test.exp)), fi.Size(), true)
+	}
+
+	list, err := layers.AssetDirInfo("in/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, 1, len(list), true)
+	assert(t, "test.asset", list[0].Name(), true)
+	assert(t, int64(16), list[0].Size(), true)
+}
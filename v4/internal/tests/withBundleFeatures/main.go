@@ -0,0 +1,73 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+//go:generate go run main.go
+
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/shuLhan/go-bindata/v4"
+)
+
+func main() {
+	cfg := &bindata.Config{
+		Package:     "bindata",
+		AssetPrefix: bindata.DefAssetPrefixName,
+		Prefix:      regexp.MustCompile(".*/testdata/"),
+		ModTime:     1586263518,
+		Ignore: []*regexp.Regexp{
+			regexp.MustCompile("split/"),
+		},
+		Input: []bindata.InputConfig{
+			bindata.CreateInputConfig("../../../testdata/in/..."),
+		},
+		HTTPHandler:   true,
+		Archive:       true,
+		Cache:         true,
+		Overlay:       true,
+		ContentType:   true,
+		HashedNames:   true,
+		VerifyOnLoad:  true,
+		Bundle:        "WebAssets",
+		BundleWrapper: true,
+	}
+
+	err := bindata.Translate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The second bundle use the Bundle type from the first one, so it
+	// must be generated with the same options.
+	cfg = &bindata.Config{
+		Package:     "bindata",
+		Output:      "bindata_doc.go",
+		AssetPrefix: bindata.DefAssetPrefixName,
+		Prefix:      regexp.MustCompile(".*/testdata/"),
+		ModTime:     1586263518,
+		Input: []bindata.InputConfig{
+			bindata.CreateInputConfig("../../../testdata/in/a/..."),
+		},
+		HTTPHandler:  true,
+		Archive:      true,
+		Cache:        true,
+		Overlay:      true,
+		ContentType:  true,
+		HashedNames:  true,
+		VerifyOnLoad: true,
+		Bundle:       "DocAssets",
+		NoBundleType: true,
+	}
+
+	err = bindata.Translate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+// nolint: gochecknoglobals
+var layers = BundleLayers{ThemeAssets, BaseAssets}
+
+func TestBundleLayers_Layer(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  *Bundle
+	}{{
+		name: "a/test.asset",
+		exp:  ThemeAssets,
+	}, {
+		name: "a",
+		exp:  ThemeAssets,
+	}, {
+		name: "b/test.asset",
+		exp:  BaseAssets,
+	}, {
+		name: "split/test.1",
+		exp:  ThemeAssets,
+	}, {
+		name: "d",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		assert(t, test.exp, layers.Layer(test.name), true)
+	}
+}
+
+func TestBundleLayers_Asset(t *testing.T) {
+	got, err := layers.Asset("split/test.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file 1\n", string(got), true)
+
+	got, err = layers.Asset("file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// Content of \"testdata/in/file name\"\n", string(got), true)
+
+	_, err = layers.Asset("d/x")
+	assert(t, "open d/x: file does not exist", err.Error(), true)
+}
+
+func TestBundleLayers_AssetInfo(t *testing.T) {
+	fi, err := layers.AssetInfo("a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, os.FileMode(0600), fi.Mode(), true)
+
+	fi, err = BaseAssets.AssetInfo("a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, os.FileMode(0644), fi.Mode(), true)
+}
+
+func TestBundleLayers_AssetNames(t *testing.T) {
+	exp := []string{
+		"a/test.asset",
+		"b/test.asset",
+		"c/test.asset",
+		"file name",
+		"split/test.1",
+		"split/test.2",
+		"test.asset",
+	}
+	assert(t, exp, layers.AssetNames(), true)
+}
+
+func TestBundleLayers_AssetDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		exp    []string
+		expErr string
+	}{{
+		name: "",
+		exp:  []string{"a", "b", "c", "file name", "split", "test.asset"},
+	}, {
+		name: "a",
+		exp:  []string{"test.asset"},
+	}, {
+		name: "split",
+		exp:  []string{"test.1", "test.2"},
+	}, {
+		name:   "test.asset",
+		expErr: "open test.asset: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		got, err := layers.AssetDir(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestBundleLayers_FS(t *testing.T) {
+	fsys := layers.FS()
+
+	err := fstest.TestFS(fsys, "a/test.asset", "b/test.asset",
+		"c/test.asset", "file name", "split/test.1", "split/test.2",
+		"test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := fs.Stat(fsys, "a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "test.asset", fi.Name(), true)
+	assert(t, os.FileMode(0600), fi.Mode(), true)
+
+	_, err = fs.ReadFile(fsys, "a")
+	assert(t, "read a: is a directory", err.Error(), true)
+
+	_, err = fs.ReadFile(fsys, "d")
+	assert(t, "read d: file does not exist", err.Error(), true)
+}
+
+func TestBundle_FS(t *testing.T) {
+	err := fstest.TestFS(BaseAssets.FS(), "a/test.asset", "b/test.asset",
+		"c/test.asset", "file name", "test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGeneratedContent(t *testing.T) {
+	for _, name := range []string{"bindata", "bindata_theme"} {
+		expFile := name + ".exp"
+		gotFile := name + ".go"
+
+		// Compare the generate file with expected.
+		exp, err := ioutil.ReadFile(expFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ioutil.ReadFile(gotFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(exp, got) {
+			t.Fatalf("%s not match with %s", expFile, gotFile)
+		}
+	}
+}
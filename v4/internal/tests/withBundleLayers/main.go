@@ -0,0 +1,62 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+//go:generate go run main.go
+
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/shuLhan/go-bindata/v4"
+)
+
+func main() {
+	cfg := &bindata.Config{
+		Package:     "bindata",
+		AssetPrefix: bindata.DefAssetPrefixName,
+		Prefix:      regexp.MustCompile(".*/testdata/in/"),
+		ModTime:     1586263518,
+		Ignore: []*regexp.Regexp{
+			regexp.MustCompile("split/"),
+		},
+		Input: []bindata.InputConfig{
+			bindata.CreateInputConfig("../../../testdata/in/..."),
+		},
+		Bundle: "BaseAssets",
+		FS:     true,
+	}
+
+	err := bindata.Translate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The theme bundle override the asset "a/test.asset" in the base
+	// bundle, with different mode.
+	cfg = &bindata.Config{
+		Package:     "bindata",
+		Output:      "bindata_theme.go",
+		AssetPrefix: bindata.DefAssetPrefixName,
+		Prefix:      regexp.MustCompile(".*/testdata/in/"),
+		Mode:        0600,
+		ModTime:     1586263518,
+		Input: []bindata.InputConfig{
+			bindata.CreateInputConfig("../../../testdata/in/a/..."),
+			bindata.CreateInputConfig("../../../testdata/in/split/..."),
+		},
+		Bundle:       "ThemeAssets",
+		NoBundleType: true,
+		FS:           true,
+	}
+
+	err = bindata.Translate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
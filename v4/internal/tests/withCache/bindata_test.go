@@ -0,0 +1,123 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sort"
+	"testing"
+)
+
+func cachedNames() (names []string) {
+	AssetCache.mu.Lock()
+	for name := range AssetCache.entries {
+		names = append(names, name)
+	}
+	AssetCache.mu.Unlock()
+	sort.Strings(names)
+	return names
+}
+
+func TestAssetCache(t *testing.T) {
+	AssetCache.Purge()
+
+	a1, err := Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(a1), true)
+	assert(t, int64(15), AssetCache.Size(), true)
+
+	a2, err := Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, &a1[0], &a2[0], true)
+	assert(t, int64(15), AssetCache.Size(), true)
+
+	for _, name := range []string{"in/b/test.asset", "in/c/test.asset"} {
+		_, err = Asset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert(t, int64(30), AssetCache.Size(), true)
+	assert(t, []string{"in/b/test.asset", "in/c/test.asset"}, cachedNames(), true)
+
+	// Accessing the asset from tree mark it as recently used.
+	_, err = _bintree.Children["in"].Children["b"].Children["test.asset"].Func()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Asset("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"in/b/test.asset", "in/test.asset"}, cachedNames(), true)
+
+	AssetCache.Purge()
+	assert(t, int64(0), AssetCache.Size(), true)
+	assert(t, 0, len(cachedNames()), true)
+}
+
+func TestAssetCache_SetMaxSize(t *testing.T) {
+	defer AssetCache.SetMaxSize(AssetCache.MaxSize())
+
+	AssetCache.Purge()
+	AssetCache.SetMaxSize(10)
+
+	_, err := Asset("in/file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(0), AssetCache.Size(), true)
+
+	AssetCache.SetMaxSize(0)
+
+	_, err = Asset("in/file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(38), AssetCache.Size(), true)
+}
+
+func TestAssetCache_Preload(t *testing.T) {
+	defer AssetCache.SetMaxSize(AssetCache.MaxSize())
+
+	AssetCache.Purge()
+	AssetCache.SetMaxSize(0)
+
+	err := AssetCache.Preload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(98), AssetCache.Size(), true)
+
+	exp := AssetNames()
+	sort.Strings(exp)
+	assert(t, exp, cachedNames(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
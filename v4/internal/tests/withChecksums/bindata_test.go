@@ -0,0 +1,89 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	content := []byte("// sample file\n")
+
+	tests := []struct {
+		algo string
+		exp  string
+	}{{
+		algo: "md5",
+		exp:  fmt.Sprintf("%x", md5.Sum(content)),
+	}, {
+		algo: "sha1",
+		exp:  fmt.Sprintf("%x", sha1.Sum(content)),
+	}, {
+		algo: "sha256",
+		exp:  fmt.Sprintf("%x", sha256.Sum256(content)),
+	}, {
+		algo: "sha512",
+		exp:  fmt.Sprintf("%x", sha512.Sum512(content)),
+	}, {
+		algo: "crc32",
+		exp:  fmt.Sprintf("%08x", crc32.ChecksumIEEE(content)),
+	}, {
+		algo: "sha384",
+	}}
+
+	a, err := _bindata["in/a/test.asset"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Log(test.algo)
+
+		assert(t, test.exp, a.info.Checksum(test.algo), true)
+	}
+}
+
+func TestIntegrity(t *testing.T) {
+	fi, err := AssetInfo("in/file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha512.Sum384([]byte("// Content of \"testdata/in/file name\"\n"))
+	exp := "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+
+	got := fi.(interface{ Integrity() string }).Integrity()
+
+	assert(t, exp, got, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
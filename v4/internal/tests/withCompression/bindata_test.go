@@ -0,0 +1,153 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const content = "// sample file\n"
+
+func TestAsset(t *testing.T) {
+	got, err := Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, content, string(got), true)
+
+	rc, err := AssetReader("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = ioutil.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, content, string(got), true)
+}
+
+func TestAssetHandler(t *testing.T) {
+	tests := []struct {
+		desc        string
+		acceptEnc   string
+		expEncoding string
+	}{{
+		desc: "Without accept encoding",
+	}, {
+		desc:      "With gzip encoding",
+		acceptEnc: "gzip",
+	}, {
+		desc:        "With deflate encoding",
+		acceptEnc:   "gzip, deflate",
+		expEncoding: "deflate",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		req := httptest.NewRequest(http.MethodGet, "/in/a/test.asset", nil)
+		if len(test.acceptEnc) > 0 {
+			req.Header.Set("Accept-Encoding", test.acceptEnc)
+		}
+		rec := httptest.NewRecorder()
+
+		AssetHandler().ServeHTTP(rec, req)
+
+		res := rec.Result()
+		assert(t, http.StatusOK, res.StatusCode, true)
+		assert(t, test.expEncoding, res.Header.Get("Content-Encoding"), true)
+
+		body := rec.Body.Bytes()
+		if len(test.expEncoding) > 0 {
+			zr, err := zlib.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err = ioutil.ReadAll(zr)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		assert(t, content, string(body), true)
+	}
+}
+
+// TestAssetHandlerETag test the ETag that is derived from the modification
+// time and size of asset, since the MD5 checksum is not generated.
+func TestAssetHandlerETag(t *testing.T) {
+	expETag := `"5e8c75de-f"`
+
+	req := httptest.NewRequest(http.MethodGet, "/in/a/test.asset", nil)
+	rec := httptest.NewRecorder()
+
+	AssetHandler().ServeHTTP(rec, req)
+
+	res := rec.Result()
+	assert(t, http.StatusOK, res.StatusCode, true)
+	assert(t, expETag, res.Header.Get("ETag"), true)
+
+	req = httptest.NewRequest(http.MethodGet, "/in/a/test.asset", nil)
+	req.Header.Set("If-None-Match", expETag)
+	rec = httptest.NewRecorder()
+
+	AssetHandler().ServeHTTP(rec, req)
+
+	assert(t, http.StatusNotModified, rec.Result().StatusCode, true)
+}
+
+// TestAssetHandlerRange test the range request on the asset that is
+// decompressed while its being send.
+func TestAssetHandlerRange(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/in/a/test.asset", nil)
+	req.Header.Set("Range", "bytes=3-8,10-")
+	rec := httptest.NewRecorder()
+
+	AssetHandler().ServeHTTP(rec, req)
+
+	res := rec.Result()
+	assert(t, http.StatusPartialContent, res.StatusCode, true)
+
+	body := rec.Body.String()
+	for _, part := range []string{"sample", "file\n"} {
+		if !strings.Contains(body, part) {
+			t.Fatalf("range body does not contain %q: %q", part, body)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/in/a/test.asset", nil)
+	req.Header.Set("Range", "bytes=3-8")
+	rec = httptest.NewRecorder()
+
+	AssetHandler().ServeHTTP(rec, req)
+
+	assert(t, "sample", rec.Body.String(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestAssetContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		exp    string
+		expErr string
+	}{{
+		name: "in/a/test.asset",
+		exp:  "text/plain; charset=utf-8",
+	}, {
+		name: "in/c/test.asset",
+		exp:  "application/x-test",
+	}, {
+		name: "in/file name",
+		exp:  "text/plain; charset=utf-8",
+	}, {
+		name:   "in/a",
+		expErr: "open in/a: file does not exist",
+	}, {
+		name:   "in/d",
+		expErr: "open in/d: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		got, err := AssetContentType(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestAssetDataURI(t *testing.T) {
+	got, err := AssetDataURI("in/c/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "data:application/x-test;base64,Ly8gc2FtcGxlIGZpbGUK", got, true)
+
+	got, err = AssetDataURI("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "data:text/plain;charset=utf-8;base64,Ly8gc2FtcGxlIGZpbGUK", got, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestAssetDir(t *testing.T) {
+	got, err := AssetDir("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	exp := []string{"a", "b", "c", "file name", "split", "test.asset"}
+	assert(t, exp, got, true)
+
+	got, err = AssetDir("in/split")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, 0, len(got), true)
+}
+
+func TestAssetInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		expName  string
+		expIsDir bool
+		expMode  os.FileMode
+		expErr   string
+	}{{
+		name:     "in",
+		expName:  "in",
+		expIsDir: true,
+		expMode:  os.ModeDir | 0755,
+	}, {
+		name:     "in/split",
+		expName:  "in/split",
+		expIsDir: true,
+		expMode:  os.ModeDir | 0755,
+	}, {
+		name:    "in/a/test.asset",
+		expName: "in/a/test.asset",
+		expMode: 0644,
+	}, {
+		name:   "in/d",
+		expErr: "open in/d: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		fi, err := AssetInfo(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+
+		assert(t, test.expName, fi.Name(), true)
+		assert(t, test.expIsDir, fi.IsDir(), true)
+		assert(t, test.expMode, fi.Mode(), true)
+		assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+	}
+}
+
+func TestAssetDirInfo(t *testing.T) {
+	list, err := AssetDirInfo("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type entry struct {
+		name  string
+		isDir bool
+	}
+	exp := []entry{
+		{"a", true},
+		{"b", true},
+		{"c", true},
+		{"file name", false},
+		{"split", true},
+		{"test.asset", false},
+	}
+	got := make([]entry, 0, len(list))
+	for _, fi := range list {
+		got = append(got, entry{fi.Name(), fi.IsDir()})
+	}
+	assert(t, exp, got, true)
+
+	_, err = AssetDirInfo("in/test.asset")
+	assert(t, "open in/test.asset: file does not exist", err.Error(), true)
+
+	_, err = AssetDirInfo("in/d")
+	assert(t, "open in/d: file does not exist", err.Error(), true)
+}
+
+func TestRestoreAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = RestoreAssets(dir, "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"in", "in/a", "in/split"} {
+		fi, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, os.ModeDir|0755, fi.Mode(), true)
+		assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "in", "a", "test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, os.FileMode(0644), fi.Mode(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
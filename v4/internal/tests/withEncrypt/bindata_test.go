@@ -0,0 +1,152 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func readKey(t *testing.T) []byte {
+	b, err := ioutil.ReadFile("../../../testdata/encrypt/key.hex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func resetKey() {
+	_bindataKey.aead = nil
+	_bindataKey.provider = nil
+}
+
+func TestAssetWithoutKey(t *testing.T) {
+	defer resetKey()
+
+	_, err := Asset("in/test.asset")
+
+	var errDecrypt *AssetDecryptError
+	assert(t, true, errors.As(err, &errDecrypt), true)
+	assert(t, "in/test.asset", errDecrypt.Name, true)
+	assert(t, true, errors.Is(err, ErrAssetKeyNotSet), true)
+
+	wrongKey := make([]byte, 32)
+	err = SetAssetKey(wrongKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Asset("in/test.asset")
+	assert(t, true, errors.As(err, &errDecrypt), true)
+
+	err = SetAssetKey(wrongKey[:16])
+	assert(t, "invalid key size 16, expecting 32", err.Error(), true)
+}
+
+// TestAssetDirInfoWithoutKey test that the file information is read without
+// decrypting the asset content.
+func TestAssetDirInfoWithoutKey(t *testing.T) {
+	list, err := AssetDirInfo("in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range list {
+		if fi.Name() == "test.asset" {
+			assert(t, int64(15), fi.Size(), true)
+			return
+		}
+	}
+	t.Fatal("test.asset is not listed")
+}
+
+func TestSetAssetKey(t *testing.T) {
+	defer resetKey()
+
+	err := SetAssetKey(readKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Asset("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+
+	errs := VerifyAssets(context.Background())
+	assert(t, []error(nil), errs, true)
+}
+
+func TestSetAssetKeyProvider(t *testing.T) {
+	defer resetKey()
+
+	var calls int
+	SetAssetKeyProvider(func() ([]byte, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("key is not ready")
+		}
+		return readKey(t), nil
+	})
+
+	_, err := Asset("in/test.asset")
+	assert(t, "Asset in/test.asset can't read by error: asset in/test.asset can't be decrypted: key is not ready",
+		err.Error(), true)
+
+	for x := 0; x < 2; x++ {
+		got, err := Asset("in/a/test.asset")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, "// sample file\n", string(got), true)
+	}
+	assert(t, 2, calls, true)
+}
+
+// TestSetAssetKeyProvider_setKey test that the provider can set the key
+// itself, since it is called without holding the lock of key.
+func TestSetAssetKeyProvider_setKey(t *testing.T) {
+	defer resetKey()
+
+	SetAssetKeyProvider(func() ([]byte, error) {
+		key := readKey(t)
+		return key, SetAssetKey(key)
+	})
+
+	got, err := Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
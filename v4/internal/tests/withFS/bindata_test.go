@@ -0,0 +1,96 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/fs"
+	"io/ioutil"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAssetFS(t *testing.T) {
+	fsys := AssetFS()
+
+	err := fstest.TestFS(fsys, "in/a/test.asset", "in/b/test.asset",
+		"in/c/test.asset", "in/file name", "in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAssetFSReadFile(t *testing.T) {
+	tests := []struct {
+		desc   string
+		name   string
+		expErr string
+		exp    string
+	}{{
+		desc:   "With directory",
+		name:   "in/a",
+		expErr: "read in/a: is a directory",
+	}, {
+		desc:   "With invalid path",
+		name:   "in/a/",
+		expErr: "read in/a/: invalid argument",
+	}, {
+		desc:   "With not exist asset",
+		name:   "in/split/test.1",
+		expErr: "read in/split/test.1: file does not exist",
+	}, {
+		desc: "With valid asset",
+		name: "in/a/test.asset",
+		exp:  "// sample file\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc, ":", test.name)
+
+		got, err := fs.ReadFile(AssetFS(), test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+
+		assert(t, test.exp, string(got), true)
+	}
+}
+
+func TestAssetFSReadDir(t *testing.T) {
+	entries, err := fs.ReadDir(AssetFS(), "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+
+	exp := []string{"a", "b", "c", "file name", "test.asset"}
+
+	assert(t, exp, got, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestAsset(t *testing.T) {
+	names := AssetNames()
+	assert(t, 12, len(names), true)
+
+	for _, name := range names {
+		exp, err := ioutil.ReadFile("../../../testdata/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Asset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, string(exp), string(got), true)
+
+		rc, err := AssetReader(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = ioutil.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, string(exp), string(got), true)
+	}
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
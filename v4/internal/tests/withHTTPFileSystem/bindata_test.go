@@ -0,0 +1,111 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAssetHTTPFileSystem(t *testing.T) {
+	srv := http.FileServer(AssetHTTPFileSystem())
+
+	tests := []struct {
+		desc    string
+		path    string
+		expCode int
+		exp     string
+	}{{
+		desc:    "With valid asset",
+		path:    "/in/a/test.asset",
+		expCode: http.StatusOK,
+		exp:     "// sample file\n",
+	}, {
+		desc:    "With not exist asset",
+		path:    "/in/split/test.1",
+		expCode: http.StatusNotFound,
+		exp:     "404 page not found\n",
+	}, {
+		desc:    "With directory",
+		path:    "/in/",
+		expCode: http.StatusOK,
+		exp: "<!doctype html>\n" +
+			"<meta name=\"viewport\" content=\"width=device-width\">\n" +
+			"<pre>\n" +
+			"<a href=\"a/\">a/</a>\n" +
+			"<a href=\"b/\">b/</a>\n" +
+			"<a href=\"c/\">c/</a>\n" +
+			"<a href=\"file%20name\">file name</a>\n" +
+			"<a href=\"test.asset\">test.asset</a>\n" +
+			"</pre>\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc, ":", test.path)
+
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		res := httptest.NewRecorder()
+
+		srv.ServeHTTP(res, req)
+
+		assert(t, test.expCode, res.Code, true)
+		assert(t, test.exp, res.Body.String(), true)
+	}
+}
+
+func TestAssetHTTPFileSystemReaddir(t *testing.T) {
+	dir, err := AssetHTTPFileSystem().Open("/in")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := dir.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, true, fi.IsDir(), true)
+
+	var got []string
+	for {
+		list, err := dir.Readdir(2)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fi := range list {
+			got = append(got, fi.Name())
+		}
+	}
+
+	exp := []string{"a", "b", "c", "file name", "test.asset"}
+
+	assert(t, exp, got, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAssetHandler(t *testing.T) {
+	const (
+		content = "// sample file\n"
+		etag    = `"ee6207419141e140476cabed422e93d3"`
+		modTime = "Tue, 07 Apr 2020 12:45:18 GMT"
+	)
+
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		header     http.Header
+		expCode    int
+		expHeader  http.Header
+		expBody    string
+		expGzipped bool
+	}{{
+		desc:    "With not exist asset",
+		method:  http.MethodGet,
+		path:    "/in/split/test.1",
+		expCode: http.StatusNotFound,
+		expBody: "404 page not found\n",
+	}, {
+		desc:    "With invalid method",
+		method:  http.MethodPost,
+		path:    "/in/a/test.asset",
+		expCode: http.StatusMethodNotAllowed,
+		expHeader: http.Header{
+			"Allow": []string{"GET, HEAD"},
+		},
+		expBody: "Method Not Allowed\n",
+	}, {
+		desc:    "Without gzip encoding",
+		method:  http.MethodGet,
+		path:    "/in/a/test.asset",
+		expCode: http.StatusOK,
+		expHeader: http.Header{
+			"Etag":          []string{etag},
+			"Last-Modified": []string{modTime},
+			"Vary":          []string{"Accept-Encoding"},
+		},
+		expBody: content,
+	}, {
+		desc:   "With gzip encoding",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"Accept-Encoding": []string{"deflate, gzip"},
+		},
+		expCode: http.StatusOK,
+		expHeader: http.Header{
+			"Content-Encoding": []string{"gzip"},
+			"Etag":             []string{`"ee6207419141e140476cabed422e93d3-gzip"`},
+			"Last-Modified":    []string{modTime},
+			"Vary":             []string{"Accept-Encoding"},
+		},
+		expBody:    content,
+		expGzipped: true,
+	}, {
+		desc:   "With gzip encoding disabled",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"Accept-Encoding": []string{"gzip;q=0"},
+		},
+		expCode: http.StatusOK,
+		expBody: content,
+	}, {
+		desc:   "With If-None-Match",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"If-None-Match": []string{etag},
+		},
+		expCode: http.StatusNotModified,
+	}, {
+		desc:   "With If-Modified-Since",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"If-Modified-Since": []string{modTime},
+		},
+		expCode: http.StatusNotModified,
+	}, {
+		desc:   "With Range",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"Range": []string{"bytes=3-8"},
+		},
+		expCode: http.StatusPartialContent,
+		expHeader: http.Header{
+			"Content-Range": []string{"bytes 3-8/15"},
+		},
+		expBody: "sample",
+	}, {
+		desc:   "With Range and gzip encoding",
+		method: http.MethodGet,
+		path:   "/in/a/test.asset",
+		header: http.Header{
+			"Accept-Encoding": []string{"gzip"},
+			"Range":           []string{"bytes=3-8"},
+		},
+		expCode:    http.StatusOK,
+		expBody:    content,
+		expGzipped: true,
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		req := httptest.NewRequest(test.method, test.path, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+		res := httptest.NewRecorder()
+
+		AssetHandler().ServeHTTP(res, req)
+
+		assert(t, test.expCode, res.Code, true)
+
+		for k, v := range test.expHeader {
+			assert(t, v, res.Header()[k], true)
+		}
+
+		body := res.Body.Bytes()
+		if test.expGzipped {
+			gz, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err = ioutil.ReadAll(gz)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assert(t, test.expBody, string(body), true)
+	}
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
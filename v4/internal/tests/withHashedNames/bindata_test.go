@@ -0,0 +1,111 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestAssetHashedName(t *testing.T) {
+	content := []byte("// sample file\n")
+	sum := fmt.Sprintf("%x", sha256.Sum256(content))
+	exp := "in/a/test." + sum[:8] + ".asset"
+
+	got, err := AssetHashedName("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, exp, got, true)
+
+	orig, err := AssetOriginalName(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/a/test.asset", orig, true)
+
+	data, err := Asset(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, content, data, true)
+
+	fi, err := AssetInfo(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/a/test.asset", fi.Name(), true)
+
+	_, err = AssetHashedName("in/a/missing.asset")
+	assert(t, true, os.IsNotExist(err), true)
+
+	_, err = AssetOriginalName("in/a/test.asset")
+	assert(t, true, os.IsNotExist(err), true)
+}
+
+func TestAssetHashedNameWithoutExt(t *testing.T) {
+	got, err := AssetHashedName("in/file name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("// Content of \"testdata/in/file name\"\n")
+	sum := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	assert(t, "in/file name."+sum[:8], got, true)
+}
+
+func TestAssetNamesWithoutHashedNames(t *testing.T) {
+	exp := []string{
+		"in/a/test.asset",
+		"in/b/test.asset",
+		"in/c/test.asset",
+		"in/file name",
+		"in/test.asset",
+	}
+
+	assert(t, exp, AssetNames(), true)
+}
+
+func TestHashedNamesManifest(t *testing.T) {
+	b, err := ioutil.ReadFile("bindata.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert(t, _bindataHashedNames, got, true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
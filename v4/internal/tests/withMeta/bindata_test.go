@@ -0,0 +1,108 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestAssetMeta(t *testing.T) {
+	tests := []struct {
+		name   string
+		exp    map[string]string
+		expErr string
+	}{{
+		name: "meta/css/main.css",
+		exp: map[string]string{
+			"cache-control": "max-age=3600",
+			"locale":        "en",
+			"owner":         "frontend",
+		},
+	}, {
+		name: "meta/index.html",
+		exp: map[string]string{
+			"cache-control": "no-cache",
+			"owner":         "web",
+		},
+	}, {
+		name: "meta/robots.txt",
+	}, {
+		name: "meta/css",
+	}, {
+		name:   "meta/index.html.meta.json",
+		expErr: "open meta/index.html.meta.json: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		got, err := AssetMeta(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, got, true)
+	}
+
+	// Modifying the returned metadata does not change the asset.
+	got, err := AssetMeta("meta/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got["owner"] = "x"
+
+	got, err = AssetMeta("meta/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "web", got["owner"], true)
+}
+
+func TestAssetInfo_Sys(t *testing.T) {
+	fi, err := AssetInfo("meta/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, ok := fi.Sys().(map[string]string)
+	assert(t, true, ok, true)
+	assert(t, "no-cache", meta["cache-control"], true)
+
+	fi, err = AssetInfo("meta/robots.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, nil, fi.Sys(), true)
+}
+
+func TestAssetNames(t *testing.T) {
+	exp := []string{
+		"meta/css/main.css",
+		"meta/index.html",
+		"meta/robots.txt",
+	}
+	assert(t, exp, AssetNames(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
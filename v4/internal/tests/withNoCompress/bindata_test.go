@@ -6,6 +6,7 @@ package bindata
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"testing"
 )
@@ -79,6 +80,50 @@ func TestAsset(t *testing.T) {
 	}
 }
 
+func TestAssetReader(t *testing.T) {
+	tests := []struct {
+		desc   string
+		name   string
+		expErr string
+		exp    string
+	}{{
+		desc:   "With invalid asset",
+		name:   "in/split/test.1",
+		expErr: "open in/split/test.1: file does not exist",
+	}, {
+		desc: "With valid asset",
+		name: "in/a/test.asset",
+		exp:  "// sample file\n",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc, ":", test.name)
+
+		rc, err := AssetReader(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+
+		_, ok := rc.(io.ReadSeeker)
+		assert(t, true, ok, true)
+		_, ok = rc.(io.ReaderAt)
+		assert(t, true, ok, true)
+
+		got, err := ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert(t, test.exp, string(got), true)
+	}
+}
+
 func TestGeneratedContent(t *testing.T) {
 	expFile := "bindata.exp"
 	gotFile := "bindata.go"
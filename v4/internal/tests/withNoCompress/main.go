@@ -28,7 +28,8 @@ func main() {
 		Input: []bindata.InputConfig{
 			bindata.CreateInputConfig("../../../testdata/in/..."),
 		},
-		NoCompress: true,
+		AssetReader: true,
+		NoCompress:  true,
 	}
 
 	err := bindata.Translate(cfg)
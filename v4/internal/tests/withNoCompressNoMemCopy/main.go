@@ -28,8 +28,9 @@ func main() {
 		Input: []bindata.InputConfig{
 			bindata.CreateInputConfig("../../../testdata/in/..."),
 		},
-		NoCompress: true,
-		NoMemCopy:  true,
+		AssetReader: true,
+		NoCompress:  true,
+		NoMemCopy:   true,
 	}
 
 	err := bindata.Translate(cfg)
@@ -28,7 +28,8 @@ func main() {
 		Input: []bindata.InputConfig{
 			bindata.CreateInputConfig("../../../testdata/in/..."),
 		},
-		NoMemCopy: true,
+		AssetReader: true,
+		NoMemCopy:   true,
 	}
 
 	err := bindata.Translate(cfg)
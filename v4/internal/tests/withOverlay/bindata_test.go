@@ -0,0 +1,209 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createOverlay(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"in/a/test.asset": "// patched file\n",
+		"in/new.txt":      "new file\n",
+		"in/d/x":          "x\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestOverlayDir(t *testing.T) {
+	assert(t, os.Getenv("BINDATA_OVERLAY_DIR"), OverlayDir(), true)
+}
+
+func TestAsset(t *testing.T) {
+	dir := createOverlay(t)
+	defer os.RemoveAll(dir)
+
+	SetOverlayDir(dir)
+	defer SetOverlayDir("")
+
+	tests := []struct {
+		name   string
+		exp    string
+		expErr string
+	}{{
+		name: "in/a/test.asset",
+		exp:  "// patched file\n",
+	}, {
+		name: "in/b/test.asset",
+		exp:  "// sample file\n",
+	}, {
+		name: "in/new.txt",
+		exp:  "new file\n",
+	}, {
+		name: "in/d/x",
+		exp:  "x\n",
+	}, {
+		name:   "in/d",
+		expErr: "open in/d: file does not exist",
+	}, {
+		name:   "in/../../in/new.txt",
+		expErr: "open in/../../in/new.txt: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		got, err := Asset(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, string(got), true)
+
+		rc, err := AssetReader(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = ioutil.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = rc.Close()
+		assert(t, test.exp, string(got), true)
+	}
+
+	SetOverlayDir("")
+
+	got, err := Asset("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+
+	_, err = Asset("in/new.txt")
+	assert(t, "open in/new.txt: file does not exist", err.Error(), true)
+}
+
+func TestAssetInfo(t *testing.T) {
+	dir := createOverlay(t)
+	defer os.RemoveAll(dir)
+
+	SetOverlayDir(dir)
+	defer SetOverlayDir("")
+
+	fi, err := AssetInfo("in/a/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/a/test.asset", fi.Name(), true)
+	assert(t, int64(16), fi.Size(), true)
+
+	fiex, ok := fi.(interface{ MD5Checksum() string })
+	assert(t, true, ok, true)
+	assert(t, fmt.Sprintf("%x", md5.Sum([]byte("// patched file\n"))), fiex.MD5Checksum(), true)
+
+	fi, err = AssetInfo("in/new.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "in/new.txt", fi.Name(), true)
+
+	fi, err = AssetInfo("in/d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, true, fi.IsDir(), true)
+
+	fi, err = AssetInfo("in/b/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+}
+
+func TestAssetDir(t *testing.T) {
+	dir := createOverlay(t)
+	defer os.RemoveAll(dir)
+
+	SetOverlayDir(dir)
+	defer SetOverlayDir("")
+
+	tests := []struct {
+		name   string
+		exp    []string
+		expErr string
+	}{{
+		name: "in",
+		exp: []string{
+			"a", "b", "c", "d", "file name", "new.txt",
+			"test.asset",
+		},
+	}, {
+		name: "in/a",
+		exp:  []string{"test.asset"},
+	}, {
+		name: "in/d",
+		exp:  []string{"x"},
+	}, {
+		name:   "in/new.txt",
+		expErr: "open in/new.txt: file does not exist",
+	}, {
+		name:   "in/e",
+		expErr: "open in/e: file does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Log(test.name)
+
+		got, err := AssetDir(test.name)
+		if err != nil {
+			assert(t, test.expErr, err.Error(), true)
+			continue
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
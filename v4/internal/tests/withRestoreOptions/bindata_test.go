@@ -0,0 +1,243 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreAssetsWithOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	names := AssetNames()
+
+	summary, err := RestoreAssetsWithOptions(ctx, dir, "in", RestoreOptions{
+		Parallel: 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, RestoreSummary{Written: names}, summary, true)
+
+	got, err := ioutil.ReadFile(filepath.Join(dir, "in", "a", "test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+
+	t.Log("With SkipUnchanged")
+
+	err = ioutil.WriteFile(filepath.Join(dir, "in", "b", "test.asset"), []byte("// changed file\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err = RestoreAssetsWithOptions(ctx, dir, "in", RestoreOptions{
+		SkipUnchanged: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := RestoreSummary{
+		Written: []string{"in/b/test.asset"},
+		Skipped: []string{
+			"in/a/test.asset",
+			"in/c/test.asset",
+			"in/file name",
+			"in/test.asset",
+		},
+	}
+	assert(t, exp, summary, true)
+
+	t.Log("With RestoreSkip")
+
+	summary, err = RestoreAssetsWithOptions(ctx, dir, "in", RestoreOptions{
+		Overwrite: RestoreSkip,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, RestoreSummary{Skipped: names}, summary, true)
+
+	t.Log("With RestoreFail")
+
+	_, err = RestoreAssetsWithOptions(ctx, dir, "in/a", RestoreOptions{
+		Overwrite: RestoreFail,
+	})
+	assert(t, true, os.IsExist(err), true)
+}
+
+func TestRestoreAssetsWithOptions_Mirror(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, "in", "x", "y"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "in", "extra"), nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "outside"), nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := RestoreAssetsWithOptions(context.Background(), dir, "in", RestoreOptions{
+		Mirror: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, []string{"in/extra", "in/x"}, summary.Removed, true)
+
+	_, err = os.Stat(filepath.Join(dir, "in", "x"))
+	assert(t, true, os.IsNotExist(err), true)
+
+	_, err = os.Stat(filepath.Join(dir, "outside"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreAssetsWithOptions_PermMask(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = RestoreAssetsWithOptions(context.Background(), dir, "in", RestoreOptions{
+		PermMask: 0044,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "in", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, os.ModeDir|0711, fi.Mode(), true)
+	assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+
+	fi, err = os.Stat(filepath.Join(dir, "in", "a", "test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, os.FileMode(0600), fi.Mode(), true)
+	assert(t, int64(1586263518), fi.ModTime().Unix(), true)
+}
+
+func TestRestoreAssetsWithOptions_invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = RestoreAssetsWithOptions(context.Background(), dir, "in/../..", RestoreOptions{})
+	assert(t, "restore in/../..: invalid argument", err.Error(), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	summary, err := RestoreAssetsWithOptions(ctx, dir, "in", RestoreOptions{})
+	assert(t, context.Canceled, err, true)
+	assert(t, 0, len(summary.Written), true)
+}
+
+func TestRestoreAssetsWithOptions_symlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	outside, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	err = os.Mkdir(filepath.Join(dir, "in"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(outside, filepath.Join(dir, "in", "a"))
+	if err != nil {
+		t.Skip(err)
+	}
+
+	_, err = RestoreAssetsWithOptions(context.Background(), dir, "in", RestoreOptions{})
+	if err == nil {
+		t.Fatal("expecting error, got nil")
+	}
+
+	names, err := ioutil.ReadDir(outside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, 0, len(names), true)
+
+	t.Log("With symlink inside the directory")
+
+	err = os.Remove(filepath.Join(dir, "in", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Mkdir(filepath.Join(dir, "real"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(dir, "real"), filepath.Join(dir, "in", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RestoreAssetsWithOptions(context.Background(), dir, "in", RestoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(dir, "real", "test.asset"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// sample file\n", string(got), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
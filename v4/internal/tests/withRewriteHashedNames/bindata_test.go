@@ -0,0 +1,77 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRewriteHashedNames(t *testing.T) {
+	html, err := Asset("rewrite/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	css, err := AssetHashedName("rewrite/css/main.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := AssetHashedName("rewrite/js/app.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exps := []string{
+		`href="` + strings.TrimPrefix(css, "rewrite/") + `"`,
+		`src=` + strings.TrimPrefix(js, "rewrite/") + `?v=1`,
+		`src="img/missing.png"`,
+	}
+	for _, exp := range exps {
+		if !bytes.Contains(html, []byte(exp)) {
+			t.Fatalf("expecting %s in rewritten HTML", exp)
+		}
+	}
+
+	// The hashed name is computed from the rewritten content.
+	sum := fmt.Sprintf("%x", sha256.Sum256(html))
+	exp := "rewrite/index." + sum[:8] + ".html"
+
+	got, err := AssetHashedName("rewrite/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, exp, got, true)
+
+	fi, err := AssetInfo(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, int64(len(html)), fi.Size(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
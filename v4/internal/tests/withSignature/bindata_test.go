@@ -0,0 +1,180 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func publicKey(t *testing.T) ed25519.PublicKey {
+	b, err := ioutil.ReadFile("../../../testdata/sign/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(b)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
+}
+
+func TestVerifySignature(t *testing.T) {
+	pub := publicKey(t)
+
+	err := VerifySignature(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifySignature(otherPub)
+	assert(t, "VerifySignature: manifest is not signed by the public key",
+		err.Error(), true)
+
+	err = VerifySignature(pub[:16])
+	assert(t, "VerifySignature: invalid public key size 16", err.Error(), true)
+}
+
+func TestVerifySignature_overlay(t *testing.T) {
+	pub := publicKey(t)
+
+	tmp, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	err = os.Mkdir(filepath.Join(tmp, "in"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmp, "in", "test.asset"), []byte("// changed\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetOverlayDir(tmp)
+	defer SetOverlayDir("")
+
+	got, err := Asset("in/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, "// changed\n", string(got), true)
+
+	// The embedded assets are verified, not the one in the overlay
+	// directory.
+	err = VerifySignature(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifySignatureDir(t *testing.T) {
+	pub := publicKey(t)
+	dir := "../../../testdata/sign/overlay"
+
+	err := VerifySignatureDir(pub, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmp, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, name := range []string{"bindata.manifest", "bindata.manifest.sig", "robots.txt"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(tmp, name), b, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = os.Mkdir(filepath.Join(tmp, "css"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmp, "css", "main.css"), []byte("body {}\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifySignatureDir(pub, tmp)
+	assert(t, "VerifySignature: css/main.css does not match the signed manifest",
+		err.Error(), true)
+
+	t.Log("With file that is not in the manifest")
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "css", "main.css"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmp, "css", "main.css"), b, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = VerifySignatureDir(pub, tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(tmp, "css", "extra.css"), []byte("body {}\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = VerifySignatureDir(pub, tmp)
+	assert(t, "VerifySignature: css/extra.css is not in the signed manifest",
+		err.Error(), true)
+
+	err = os.Remove(filepath.Join(tmp, "css", "extra.css"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(tmp, "robots.txt"), filepath.Join(tmp, "link.txt"))
+	if err != nil {
+		t.Skip(err)
+	}
+	err = VerifySignatureDir(pub, tmp)
+	assert(t, "VerifySignature: link.txt is not in the signed manifest",
+		err.Error(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
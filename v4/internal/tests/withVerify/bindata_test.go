@@ -0,0 +1,94 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+func TestVerifyAssets(t *testing.T) {
+	errs := VerifyAssets(context.Background())
+	assert(t, []error(nil), errs, true)
+
+	orig := make(map[string]bindataRaw, len(_bindataRaw))
+	for name, raw := range _bindataRaw {
+		orig[name] = raw
+	}
+	defer func() {
+		_bindataRaw = orig
+	}()
+
+	raw := _bindataRaw["in/a/test.asset"]
+	raw.info.size = 16
+	_bindataRaw["in/a/test.asset"] = raw
+
+	raw = _bindataRaw["in/test.asset"]
+	raw.info.md5checksum = "00000000000000000000000000000000"
+	_bindataRaw["in/test.asset"] = raw
+
+	exp := []string{
+		"asset in/a/test.asset is corrupted: size is 15, expecting 16",
+		"asset in/test.asset is corrupted: MD5 checksum is ee6207419141e140476cabed422e93d3, expecting 00000000000000000000000000000000",
+	}
+
+	errs = VerifyAssets(context.Background())
+
+	got := make([]string, 0, len(errs))
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	assert(t, exp, got, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs = VerifyAssets(ctx)
+	assert(t, []error{context.Canceled}, errs, true)
+}
+
+func TestVerifyOnLoad(t *testing.T) {
+	_, err := Asset("in/b/test.asset")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := bindataInBTestAssetInfo
+	defer func() {
+		bindataInBTestAssetInfo = orig
+	}()
+
+	bindataInBTestAssetInfo.md5checksum = "00000000000000000000000000000000"
+
+	exp := "Asset in/b/test.asset can't read by error: asset in/b/test.asset is corrupted: MD5 checksum is ee6207419141e140476cabed422e93d3, expecting 00000000000000000000000000000000"
+
+	_, err = Asset("in/b/test.asset")
+	if err == nil {
+		t.Fatal("expecting error, got nil")
+	}
+	assert(t, exp, err.Error(), true)
+}
+
+func TestGeneratedContent(t *testing.T) {
+	expFile := "bindata.exp"
+	gotFile := "bindata.go"
+
+	// Compare the generate bindata.go with expected.
+	exp, err := ioutil.ReadFile(expFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadFile(gotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(exp, got) {
+		t.Fatalf("%s not match with %s", expFile, gotFile)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// metaFileSuffix is the suffix of sidecar file that contains the metadata
+// of asset, for example "logo.png.meta.json" for "logo.png".
+const metaFileSuffix = ".meta.json"
+
+// MetaConfig define the metadata of the assets whose path match the
+// pattern.
+type MetaConfig struct {
+	Pattern *regexp.Regexp
+	Meta    map[string]string
+}
+
+// withMeta return true if the metadata of assets is generated.
+func (c *Config) withMeta() bool {
+	return len(c.Meta) > 0 || c.MetaFiles
+}
+
+// isMetaFile return true if the path is the sidecar file of asset metadata.
+func (c *Config) isMetaFile(path string) bool {
+	return c.MetaFiles && strings.HasSuffix(path, metaFileSuffix)
+}
+
+// assetMeta return the metadata of asset.
+//
+// (1) The metadata from all Meta patterns that match the asset path are
+// merged, where the later pattern override the same key from the previous
+// one.
+// (2) If MetaFiles is set, the metadata from the sidecar file override the
+// metadata from Meta.
+func assetMeta(c *Config, ast *asset) (meta map[string]string, err error) {
+	// (1)
+	for _, mc := range c.Meta {
+		if !mc.Pattern.MatchString(ast.path) {
+			continue
+		}
+		if meta == nil {
+			meta = make(map[string]string, len(mc.Meta))
+		}
+		for key, val := range mc.Meta {
+			meta[key] = val
+		}
+	}
+
+	// (2)
+	if !c.MetaFiles {
+		return meta, nil
+	}
+
+	metaPath := ast.path + metaFileSuffix
+
+	b, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil
+		}
+		return nil, err
+	}
+
+	var fileMeta map[string]string
+
+	err = json.Unmarshal(b, &fileMeta)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", metaPath, err)
+	}
+
+	if meta == nil {
+		meta = make(map[string]string, len(fileMeta))
+	}
+	for key, val := range fileMeta {
+		meta[key] = val
+	}
+
+	return meta, nil
+}
+
+// formatMeta return the metadata as Go map literal, sorted by key, indented
+// with the number of tabs.
+func formatMeta(meta map[string]string, indent int) string {
+	if meta == nil {
+		return "nil"
+	}
+
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+
+	tabs := strings.Repeat("\t", indent)
+	sb.WriteString("map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "%s\t%q: %q,\n", tabs, key, meta[key])
+	}
+	sb.WriteString(tabs + "}")
+
+	return sb.String()
+}
+
+// writeMeta writes the function AssetMeta in the scope s.
+func writeMeta(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplMeta)
+}
+
+const tmplFileInfoSys = `func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+`
+
+const tmplFileInfoSysMeta = `
+// Sys return the metadata of asset as map[string]string, or nil if the asset
+// does not have metadata.
+// The returned map is shared, it should not be modified.
+func (fi bindataFileInfo) Sys() interface{} {
+	if fi.meta == nil {
+		return nil
+	}
+	return fi.meta
+}
+`
+
+const tmplMeta = `
+//
+// AssetMeta return the copy of metadata of asset, that is set from the
+// configuration or the sidecar file when the code is generated.
+// It return nil if the asset does not have metadata.
+//
+{{.Decl "AssetMeta"}}(name string) (map[string]string, error) {
+	info, err := {{.Ref "AssetInfo"}}(name)
+	if err != nil {
+		return nil, err
+	}
+	meta, _ := info.Sys().(map[string]string)
+	if meta == nil {
+		return nil, nil
+	}
+	dup := make(map[string]string, len(meta))
+	for key, val := range meta {
+		dup[key] = val
+	}
+	return dup, nil
+}
+`
@@ -0,0 +1,82 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestAssetMeta(t *testing.T) {
+	cfg := &Config{
+		Meta: []MetaConfig{{
+			Pattern: regexp.MustCompile(`\.html$`),
+			Meta: map[string]string{
+				"cache-control": "max-age=3600",
+				"owner":         "frontend",
+			},
+		}, {
+			Pattern: regexp.MustCompile(`index`),
+			Meta: map[string]string{
+				"locale": "en",
+				"owner":  "home",
+			},
+		}},
+	}
+
+	tests := []struct {
+		desc      string
+		path      string
+		metaFiles bool
+		exp       map[string]string
+	}{{
+		desc: `With merged patterns`,
+		path: "testdata/meta/index.html",
+		exp: map[string]string{
+			"cache-control": "max-age=3600",
+			"locale":        "en",
+			"owner":         "home",
+		},
+	}, {
+		desc:      `With sidecar file`,
+		path:      "testdata/meta/index.html",
+		metaFiles: true,
+		exp: map[string]string{
+			"cache-control": "no-cache",
+			"locale":        "en",
+			"owner":         "web",
+		},
+	}, {
+		desc:      `Without metadata`,
+		path:      "testdata/meta/robots.txt",
+		metaFiles: true,
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		cfg.MetaFiles = test.metaFiles
+
+		got, err := assetMeta(cfg, &asset{path: test.path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(t, test.exp, got, true)
+	}
+}
+
+func TestFormatMeta(t *testing.T) {
+	meta := map[string]string{
+		"owner":         "web",
+		"cache-control": "no-cache",
+	}
+	exp := "map[string]string{\n" +
+		"\t\t\"cache-control\": \"no-cache\",\n" +
+		"\t\t\"owner\": \"web\",\n" +
+		"\t}"
+
+	assert(t, exp, formatMeta(meta, 1), true)
+	assert(t, "nil", formatMeta(nil, 1), true)
+}
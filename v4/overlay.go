@@ -0,0 +1,294 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// overlayImports contains list of packages required by the generated
+// overlay directory.
+// nolint: gochecknoglobals
+var overlayImports = []string{
+	"crypto/md5", "fmt", "hash", "io", "io/ioutil", "os", "path/filepath",
+	"sort", "strings", "sync",
+}
+
+// writeOverlay writes the type that read the asset from overlay directory,
+// and the function SetOverlayDir and OverlayDir in the scope s.
+func writeOverlay(w io.Writer, c *Config, s genScope) (err error) {
+	if !s.Bundle() {
+		_, err = fmt.Fprintf(w, tmplOverlayVar, c.OverlayEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeScoped(w, s, tmplOverlay)
+	if err != nil {
+		return err
+	}
+
+	return writeOverlayChecksum(w, c)
+}
+
+const tmplOverlayVar = `
+// _bindataOverlay contains the directory that override the embedded assets.
+// nolint: gochecknoglobals
+var _bindataOverlay = bindataNewOverlay(%q)
+`
+
+const tmplOverlay = `
+// bindataOverlay contains the directory that override the embedded assets.
+type bindataOverlay struct {
+	sync.RWMutex
+	dir string
+}
+
+// bindataNewOverlay create the overlay, with the directory read from the
+// environment variable env, if its not empty.
+func bindataNewOverlay(env string) *bindataOverlay {
+	o := &bindataOverlay{}
+	if len(env) != 0 {
+		o.dir = os.Getenv(env)
+	}
+	return o
+}
+
+//
+// SetOverlayDir set the directory on disk that override the embedded assets
+{{- if .Bundle}}
+// in the bundle{{end}}.
+// Asset, AssetInfo, and AssetDir check the file in the overlay directory
+// first, and fall back to the embedded asset if the file does not exist.
+// The file names returned by AssetDir are merged from both the overlay
+// directory and the embedded assets.
+// Empty dir disable the overlay.
+//
+{{.Decl "SetOverlayDir"}}(dir string) {
+	{{.Field "overlay"}}.Lock()
+	{{.Field "overlay"}}.dir = dir
+	{{.Field "overlay"}}.Unlock()
+}
+
+// OverlayDir return the current overlay directory{{if .Bundle}} of bundle{{end}}.
+{{.Decl "OverlayDir"}}() string {
+	{{.Field "overlay"}}.RLock()
+	defer {{.Field "overlay"}}.RUnlock()
+	return {{.Field "overlay"}}.dir
+}
+
+// path return the path of asset name in the overlay directory, or empty
+// string if overlay is not set or the name contains "..".
+func (o *bindataOverlay) path(name string) string {
+	o.RLock()
+	dir := o.dir
+	o.RUnlock()
+	if len(dir) == 0 {
+		return ""
+	}
+	for _, p := range strings.Split(name, "/") {
+		if p == ".." {
+			return ""
+		}
+	}
+	return filepath.Join(dir, filepath.FromSlash(name))
+}
+
+// open read the asset name from the overlay directory.
+// It returns nil asset if the file does not exist in the overlay directory.
+func (o *bindataOverlay) open(name string) (*asset, error) {
+	p := o.path(name)
+	if len(p) == 0 {
+		return nil, nil
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	info := bindataOverlayInfo{FileInfo: fi, name: name, path: p}
+	return &asset{bytes: data, info: info}, nil
+}
+
+// stat return the file information of file or directory name in the overlay
+// directory.
+// It returns nil if the name does not exist in the overlay directory.
+func (o *bindataOverlay) stat(name string) (os.FileInfo, error) {
+	p := o.path(name)
+	if len(p) == 0 {
+		return nil, nil
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if fi.IsDir() {
+		return fi, nil
+	}
+	return bindataOverlayInfo{FileInfo: fi, name: name, path: p}, nil
+}
+
+// readDir return the file names in the overlay directory name, and true if
+// the directory exist.
+func (o *bindataOverlay) readDir(name string) ([]string, bool) {
+	p := o.path(name)
+	if len(p) == 0 {
+		return nil, false
+	}
+	d, err := os.Open(p)
+	if err != nil {
+		return nil, false
+	}
+	defer d.Close()
+	fi, err := d.Stat()
+	if err != nil || !fi.IsDir() {
+		return nil, false
+	}
+	names, err := d.Readdirnames(0)
+	if err != nil {
+		return nil, false
+	}
+	return names, true
+}
+
+// wrap return the function that read the asset name from the overlay
+// directory, or using f if its not exist.
+func (o *bindataOverlay) wrap(name string, f func() (*asset, error)) func() (*asset, error) {
+	return func() (*asset, error) {
+		a, err := o.open(name)
+		if a != nil || err != nil {
+			return a, err
+		}
+		return f()
+	}
+}
+` + tmplOverlayInfo
+
+// tmplOverlayInfo contains the file information of asset in the overlay
+// directory.
+const tmplOverlayInfo = `
+// bindataOverlayInfo define the file information of asset in the overlay
+// directory.
+// The checksum of file is computed only when its requested, by reading the
+// file at path.
+type bindataOverlayInfo struct {
+	os.FileInfo
+	name string
+	path string
+}
+
+func (fi bindataOverlayInfo) Name() string {
+	return fi.name
+}
+func (fi bindataOverlayInfo) MD5Checksum() string {
+	return fmt.Sprintf("%x", fi.sum(md5.New()))
+}
+
+// sum return the hash of file content, or nil if the file can not be read.
+func (fi bindataOverlayInfo) sum(h hash.Hash) []byte {
+	f, err := os.Open(fi.path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil
+	}
+	return h.Sum(nil)
+}
+`
+
+// tmplOverlayAsset is the part of function Asset that read the asset from
+// overlay directory, if its not embedded.
+const tmplOverlayAsset = `	if a, err := {{.Field "overlay"}}.open(cannonicalName); a != nil || err != nil {
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %w", name, err)
+		}
+		return a.bytes, nil
+	}
+`
+
+// tmplOverlayAssetInfo is the part of function AssetInfo that read the
+// asset information from overlay directory, if its not embedded.
+const tmplOverlayAssetInfo = `	if fi, err := {{.Field "overlay"}}.stat(cannonicalName); fi != nil || err != nil {
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %w", name, err)
+		}
+		return fi, nil
+	}
+`
+
+// tmplOverlayRawOpen is the part of function bindataRawOpen that read the
+// asset from overlay directory.
+const tmplOverlayRawOpen = `
+	if a, err := {{.Field "overlay"}}.open(cannonicalName); a != nil || err != nil {
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return bytes.NewReader(a.bytes), a.info, false, nil
+	}
+`
+
+// tmplOverlayNodeInfo is the part of function bindataNodeInfo that read the
+// file information from overlay directory.
+const tmplOverlayNodeInfo = `
+	if fi, err := {{.Field "overlay"}}.stat(name); fi != nil || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return bindataBaseInfo{FileInfo: fi, name: path.Base(name)}, nil
+	}`
+
+// tmplFuncAssetDirOverlay is the function AssetDir that merge the file names
+// from the overlay directory.
+const tmplFuncAssetDirOverlay = tmplFuncAssetDirDoc + `{{.Decl "AssetDir"}}(name string) ([]string, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	node := {{.Ref "node"}}(cannonicalName)
+	if node != nil && node.Func != nil {
+		node = nil
+	}
+	overlayNames, ok := {{.Field "overlay"}}.readDir(cannonicalName)
+	if node == nil && !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	uniq := make(map[string]struct{}, len(overlayNames))
+	for _, childName := range overlayNames {
+		uniq[childName] = struct{}{}
+	}
+	if node != nil {
+		for childName := range node.Children {
+			uniq[childName] = struct{}{}
+		}
+	}
+	rv := make([]string, 0, len(uniq))
+	for childName := range uniq {
+		rv = append(rv, childName)
+	}
+	sort.Strings(rv)
+	return rv, nil
+}
+`
+
+// tmplOverlayExist is the part of method exist of Bundle that lookup the
+// file or directory in the overlay directory.
+const tmplOverlayExist = `
+	if fi, err := {{.Field "overlay"}}.stat(name); fi != nil || err != nil {
+		return true
+	}`
@@ -0,0 +1,116 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// queryImports contains list of packages required by the generated
+// functions to query the assets names.
+// nolint: gochecknoglobals
+var queryImports = []string{
+	"os", "path", "path/filepath", "sort", "strings",
+}
+
+// writeQuery writes the functions to walk the assets tree and to query the
+// assets names by pattern or prefix, in the scope s.
+func writeQuery(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplQuery)
+}
+
+const tmplQuery = `
+//
+// AssetWalk walks the assets tree rooted at root, calling fn for each file
+// and directory in the tree, including root, in lexical order.
+// The name passed to fn is the asset name, and root "" walk all assets
+// without calling fn for the root itself.
+// As in filepath.Walk, if fn returns filepath.SkipDir when invoked on a
+// directory, the directory is skipped, and when invoked on a file, the
+// remaining files in the directory are skipped.
+//
+{{.Decl "AssetWalk"}}(root string, fn func(name string, info os.FileInfo, err error) error) error {
+	cannonicalName := strings.Replace(root, "\\", "/", -1)
+	node := {{.Ref "node"}}(cannonicalName)
+	if node == nil {
+		return fn(root, nil, &os.PathError{Op: "open", Path: root, Err: os.ErrNotExist})
+	}
+	err := {{.Ref "walk"}}(cannonicalName, node, fn)
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+{{.Decl "walk"}}(name string, node *bintree, fn func(name string, info os.FileInfo, err error) error) error {
+	if len(name) != 0 {
+		info, err := {{.Ref "nodeInfo"}}(name, node)
+		if err != nil {
+			return fn(name, nil, err)
+		}
+		err = fn(name, info, nil)
+		if err != nil || node.Func != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		names = append(names, childName)
+	}
+	sort.Strings(names)
+
+	for _, childName := range names {
+		child := node.Children[childName]
+		err := {{.Ref "walk"}}(path.Join(name, childName), child, fn)
+		if err == nil {
+			continue
+		}
+		if err != filepath.SkipDir {
+			return err
+		}
+		if child.Func != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+//
+// AssetGlob returns the sorted names of all assets matching pattern, using
+// the path.Match syntax.
+// The only possible returned error is path.ErrBadPattern.
+//
+{{.Decl "AssetGlob"}}(pattern string) ([]string, error) {
+	_, err := path.Match(pattern, "")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for name := range {{.Field "files"}} {
+		ok, _ := path.Match(pattern, name)
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+//
+// AssetNamesWithPrefix returns the sorted names of all assets that begin
+// with prefix.
+//
+{{.Decl "AssetNamesWithPrefix"}}(prefix string) []string {
+	names := make([]string, 0)
+	for name := range {{.Field "files"}} {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+`
@@ -0,0 +1,193 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// addRawImports add the packages required by the generated function
+// bindataRawOpen.
+func addRawImports(c *Config, imports goImports) {
+	if !c.withAssetReader() {
+		return
+	}
+	imports.add("io", "os", "strings")
+	// The asset in the overlay directory is read using bytes.Reader.
+	if c.Debug || c.Dev || !c.NoMemCopy || c.Overlay {
+		imports.add("bytes")
+	}
+}
+
+// writeRaw writes the table of embedded data and information of each asset,
+// which is always written in release mode, since its used to read the asset
+// information without decompressing the asset.
+// The function bindataRawOpen, that return the reader of asset data as it is
+// embedded in the code, is written only if its required by AssetReader.
+//
+// In debug mode, the table is not written and the data is read from disk.
+func writeRaw(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	if c.Debug || c.Dev {
+		return writeRawOpen(w, c, genScope{})
+	}
+
+	dataType, field := rawFields(c)
+	_, err = fmt.Fprintf(w, tmplRawType, dataType, field)
+	if err != nil {
+		return err
+	}
+
+	err = writeRawOpen(w, c, genScope{})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, tmplRawTable)
+	if err != nil {
+		return err
+	}
+
+	err = writeRawEntries(w, c, keys, toc)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+
+	return err
+}
+
+// writeRawOpen writes the function rawOpen in the scope s, if its required
+// by AssetReader.
+func writeRawOpen(w io.Writer, c *Config, s genScope) error {
+	if !c.withAssetReader() {
+		return nil
+	}
+
+	hooks := lookupHooks(c)
+	if c.Debug || c.Dev {
+		return writeScoped(w, s.withHooks(hooks), tmplRawOpenDebug)
+	}
+	hooks["rawData"] = rawOpenData(c, `{{.Field "key"}}.decrypt`)
+	return writeScoped(w, s.withHooks(hooks), tmplRawOpenRelease)
+}
+
+// rawFields return the type of embedded data, and the field that record
+// whether the data is compressed, which is only set in adaptive mode.
+func rawFields(c *Config) (dataType, field string) {
+	dataType = "[]byte"
+	if c.NoMemCopy {
+		dataType = "string"
+	}
+	if c.AdaptiveCompression {
+		field = "\tcompressed bool\n"
+	}
+	return dataType, field
+}
+
+// rawOpenData return the end of function that return the reader for the
+// embedded data of raw, the asset information, and whether the data is
+// compressed.
+// The encrypted data is decrypted using the function decrypt.
+func rawOpenData(c *Config, decrypt string) string {
+	compressed := strconv.FormatBool(!c.NoCompress)
+	if c.AdaptiveCompression {
+		compressed = "raw.compressed"
+	}
+	if len(c.EncryptKey) > 0 {
+		return fmt.Sprintf(tmplRawOpenEncrypt, decrypt, compressed)
+	}
+	readerPkg := "bytes"
+	if c.NoMemCopy {
+		readerPkg = "strings"
+	}
+	return fmt.Sprintf(tmplRawOpen, readerPkg, compressed)
+}
+
+// writeRawEntries writes the entries in the table of embedded data, mapped
+// to the asset name.
+func writeRawEntries(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	longestNameLen := getLongestAssetNameLen(keys)
+
+	for _, key := range keys {
+		ast := toc[key]
+		var compressed string
+		if c.AdaptiveCompression {
+			compressed = fmt.Sprintf(", compressed: %t", ast.compressed)
+		}
+		_, err = fmt.Fprintf(w, "\t%q:%*s{data: _%s, info: %sInfo%s},\n",
+			ast.name, longestNameLen-len(ast.name)+1, "",
+			ast.funcName, ast.funcName, compressed)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const tmplRawReader = `
+// bindataRawReader is the reader for the data of asset, as it is embedded in
+// the code.
+type bindataRawReader interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	Size() int64
+}
+`
+
+const tmplRawType = `
+type bindataRaw struct {
+	data %s
+	info bindataFileInfo
+%s}
+`
+
+const tmplRawTable = `
+//
+// _bindataRaw is a table, holding the embedded data and information of each
+// asset, mapped to its name.
+//
+var _bindataRaw = map[string]bindataRaw{
+`
+
+// tmplRawOpenRelease contains the function rawOpen until the embedded data
+// is found, where the rest of function is inserted by the hook "rawData".
+// The lookup of hashed name and overlay is inserted by the hook "rawOpen".
+const tmplRawOpenRelease = tmplRawReader + `
+// {{.Name "rawOpen"}} returns the reader for the data of asset name as it
+// is embedded in the code, the asset information, and true if the data is
+// compressed.
+{{.Decl "rawOpen"}}(name string) (bindataRawReader, os.FileInfo, bool, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1){{.Hook "rawOpen"}}
+	raw, ok := {{.Field "raw"}}[cannonicalName]
+	if !ok {
+		return nil, nil, false, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+{{.Hook "rawData"}}`
+
+const tmplRawOpen = `	return %s.NewReader(raw.data), raw.info, %s, nil
+}
+`
+
+const tmplRawOpenDebug = tmplRawReader + `
+// {{.Name "rawOpen"}} returns the reader for the data of asset name, the
+// asset information, and false since the data is never compressed in debug
+// mode.
+{{.Decl "rawOpen"}}(name string) (bindataRawReader, os.FileInfo, bool, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1){{.Hook "rawOpen"}}
+	f, ok := {{.Field "files"}}[cannonicalName]
+	if !ok {
+		return nil, nil, false, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	a, err := f()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return bytes.NewReader(a.bytes), a.info, false, nil
+}
+`
@@ -0,0 +1,101 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// addReaderImports add the packages required by the generated function
+// AssetReader.
+func addReaderImports(c *Config, imports goImports) {
+	if !c.withAssetReader() {
+		return
+	}
+	addRawImports(c, imports)
+	imports.add("io")
+	if !c.Debug && !c.Dev && !c.NoCompress {
+		imports.add("fmt")
+	}
+}
+
+// withAssetReader return true if the function AssetReader is generated,
+// because its set by AssetReader option or its used by other options.
+func (c *Config) withAssetReader() bool {
+	return c.AssetReader || c.HTTPHandler || c.Archive
+}
+
+// writeAssetReader writes the function AssetReader that return the streaming
+// reader for the asset content, in the scope s.
+func writeAssetReader(w io.Writer, c *Config, s genScope) error {
+	if !c.withAssetReader() {
+		return nil
+	}
+	tmpl := tmplAssetReaderCompress
+	if c.Debug || c.Dev || c.NoCompress {
+		tmpl = tmplAssetReaderNocompress
+	}
+	return writeScoped(w, s, tmpl)
+}
+
+const tmplAssetReaderDoc = `
+//
+// AssetReader returns a reader for the content of the asset name.
+// The content is decompressed while it is being read, instead of loading the
+// whole asset into memory.
+// If the asset is not compressed, the returned reader also implements
+// io.Seeker and io.ReaderAt, which read the embedded data without copying it.
+// The caller should close the reader after use.
+//`
+
+const tmplAssetReader = tmplAssetReaderDoc + `
+{{.Decl "AssetReader"}}(name string) (io.ReadCloser, error) {
+	rc, _, err := {{.Ref "assetOpen"}}(name)
+	return rc, err
+}
+`
+
+const tmplAssetReaderCompress = tmplAssetReader + `
+// {{.Name "assetOpen"}} returns the reader for the content of the asset name
+// and the asset information that is stored along with the embedded data.
+{{.Decl "assetOpen"}}(name string) (io.ReadCloser, os.FileInfo, error) {
+	raw, info, compressed, err := {{.Ref "rawOpen"}}(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !compressed {
+		return bindataNopCloser{raw}, info, nil
+	}
+	rc, err := bindataDecompress(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AssetReader %s can't read by error: %w", name, err)
+	}
+	return rc, info, nil
+}
+` + tmplNopCloser
+
+const tmplAssetReaderNocompress = tmplAssetReader + `
+// {{.Name "assetOpen"}} returns the reader for the content of the asset name
+// and the asset information.
+{{.Decl "assetOpen"}}(name string) (io.ReadCloser, os.FileInfo, error) {
+	raw, info, _, err := {{.Ref "rawOpen"}}(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bindataNopCloser{raw}, info, nil
+}
+` + tmplNopCloser
+
+const tmplNopCloser = `
+// bindataNopCloser implements io.ReadCloser, io.Seeker, and io.ReaderAt on
+// top of the embedded data.
+type bindataNopCloser struct {
+	bindataRawReader
+}
+
+func (bindataNopCloser) Close() error {
+	return nil
+}
+`
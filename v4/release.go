@@ -6,8 +6,6 @@ package bindata
 
 import (
 	"bytes"
-	"compress/gzip"
-	"crypto/md5" //nolint: gas
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -27,7 +25,11 @@ func writeOneFileRelease(w io.Writer, c *Config, ast *asset) (err error) {
 
 // writeRelease writes the release code file for single file.
 func writeRelease(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
-	err = writeReleaseHeader(w, c)
+	if c.NoBundleType && len(c.Bundle) > 0 {
+		_, err = io.WriteString(w, tmplImport)
+	} else {
+		err = writeReleaseHeader(w, c)
+	}
 	if err != nil {
 		return err
 	}
@@ -45,48 +47,118 @@ func writeRelease(w io.Writer, c *Config, keys []string, toc map[string]*asset)
 // writeReleaseHeader writes output file headers.
 // This targets release builds.
 func writeReleaseHeader(w io.Writer, c *Config) (err error) {
+	imports := newGoImports("fmt", "io/ioutil", "os", "path/filepath",
+		"strings", "time")
+
+	var tmplBindataRead string
+
 	if c.NoCompress {
 		if c.NoMemCopy {
-			_, err = w.Write([]byte(tmplImportNocompressNomemcopy))
-		} else {
-			_, err = w.Write([]byte(tmplImportNocompressMemcopy))
+			imports.add("reflect", "unsafe")
+			tmplBindataRead = tmplBindataReadNocompressNomemcopy
 		}
 	} else {
-		if c.NoMemCopy {
-			_, err = w.Write([]byte(tmplImportCompressNomemcopy))
-		} else {
-			_, err = w.Write([]byte(tmplImportCompressMemcopy))
+		imports.add("bytes", "io")
+		imports.add(c.compressor.Imports()...)
+		switch {
+		case c.AdaptiveCompression && c.NoMemCopy:
+			imports.add("reflect", "unsafe")
+			tmplBindataRead = tmplBindataReadAdaptiveNomemcopy
+		case c.AdaptiveCompression:
+			tmplBindataRead = tmplBindataReadAdaptiveMemcopy
+		case c.NoMemCopy:
+			tmplBindataRead = tmplBindataReadCompressNomemcopy
+		default:
+			tmplBindataRead = tmplBindataReadCompressMemcopy
 		}
 	}
+
+	imports.add(nodeInfoImports...)
+	imports.add(queryImports...)
+	addReaderImports(c, imports)
+	addFeatureImports(c, imports)
+
+	_, err = io.WriteString(w, "\n")
+	if err != nil {
+		return err
+	}
+
+	err = imports.write(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(w, "\n", tmplBindataRead)
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprint(w, tmplReleaseHeader)
+	if !c.NoCompress {
+		err = writeDecompress(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	ifaceMethods, fields, methods := fileInfoOptionals(c)
+
+	_, err = fmt.Fprintf(w, tmplReleaseHeader, ifaceMethods, fields, methods)
 
 	return err
 }
 
+// fileInfoOptionals return the methods of fileInfoEx interface, and the
+// fields and methods of bindataFileInfo, that depends on the options.
+func fileInfoOptionals(c *Config) (ifaceMethods, fields, methods string) {
+	sysMethod := tmplFileInfoSys
+
+	if c.ContentType {
+		fields += "\tcontentType string\n"
+		methods += tmplFileInfoContentType
+	}
+	if c.withMeta() {
+		fields += "\tmeta        map[string]string\n"
+		sysMethod = tmplFileInfoSysMeta
+	}
+	if len(c.Checksums) > 0 {
+		ifaceMethods += "\tChecksum(algo string) string\n"
+		fields += "\tchecksums   map[string]string\n"
+		methods += tmplFileInfoChecksum
+	}
+	if c.Integrity {
+		ifaceMethods += "\tIntegrity() string\n"
+		fields += "\tintegrity   string\n"
+		methods += tmplFileInfoIntegrity
+	}
+
+	return ifaceMethods, fields, methods + sysMethod
+}
+
 // writeReleaseAsset write a release entry for the given asset.
 // A release entry is a function which embeds and returns
 // the file's byte content.
 func writeReleaseAsset(w io.Writer, c *Config, ast *asset) (err error) {
-	fd, err := os.Open(ast.path)
+	fd, err := openAsset(ast)
 	if err != nil {
-		return
+		return err
 	}
 
-	if c.NoCompress {
+	hashes := newAssetHashes(c)
+	r := hashes.wrap(fd)
+
+	if len(c.EncryptKey) > 0 {
+		err = encryptAsset(w, c, ast, r)
+	} else if !ast.compressed {
 		if c.NoMemCopy {
-			err = nocompressNomemcopy(w, ast, fd)
+			err = nocompressNomemcopy(w, c, ast, r)
 		} else {
-			err = nocompressMemcopy(w, ast, fd)
+			err = nocompressMemcopy(w, c, ast, r)
 		}
 	} else {
 		if c.NoMemCopy {
-			err = compressNomemcopy(w, ast, fd)
+			err = compressNomemcopy(w, c, ast, r)
 		} else {
-			err = compressMemcopy(w, ast, fd)
+			err = compressMemcopy(w, c, ast, r)
 		}
 	}
 	if err != nil {
@@ -99,7 +171,7 @@ func writeReleaseAsset(w io.Writer, c *Config, ast *asset) (err error) {
 		return
 	}
 
-	return assetReleaseCommon(w, c, ast)
+	return assetReleaseCommon(w, c, ast, hashes)
 }
 
 // nolint: gochecknoglobals
@@ -155,22 +227,19 @@ func sanitizeChunks(buf *bytes.Buffer, chunks [][]byte) {
 	buf.WriteString("`")
 }
 
-func compressNomemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
+func compressNomemcopy(w io.Writer, c *Config, ast *asset, r io.Reader) (err error) {
 	_, err = fmt.Fprintf(w, `var _%s = "`, ast.funcName)
 	if err != nil {
 		return
 	}
 
-	gz := gzip.NewWriter(&stringWriter{Writer: w})
-	_, err = io.Copy(gz, r)
+	err = compress(&stringWriter{Writer: w}, c, r)
 	if err != nil {
-		_ = gz.Close()
 		return
 	}
 
-	err = gz.Close()
-	if err != nil {
-		return
+	if c.AdaptiveCompression {
+		return writeAdaptiveFunc(w, ast, `"`)
 	}
 
 	_, err = fmt.Fprintf(w, tmplFuncCompressNomemcopy, ast.funcName,
@@ -179,22 +248,19 @@ func compressNomemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
 	return
 }
 
-func compressMemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
+func compressMemcopy(w io.Writer, c *Config, ast *asset, r io.Reader) (err error) {
 	_, err = fmt.Fprintf(w, `var _%s = []byte("`, ast.funcName)
 	if err != nil {
 		return err
 	}
 
-	gz := gzip.NewWriter(&stringWriter{Writer: w})
-	_, err = io.Copy(gz, r)
+	err = compress(&stringWriter{Writer: w}, c, r)
 	if err != nil {
-		_ = gz.Close()
-		return err
+		return
 	}
 
-	err = gz.Close()
-	if err != nil {
-		return
+	if c.AdaptiveCompression {
+		return writeAdaptiveFunc(w, ast, `")`)
 	}
 
 	_, err = fmt.Fprintf(w, tmplFuncCompressMemcopy, ast.funcName,
@@ -203,7 +269,7 @@ func compressMemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
 	return
 }
 
-func nocompressNomemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
+func nocompressNomemcopy(w io.Writer, c *Config, ast *asset, r io.Reader) (err error) {
 	_, err = fmt.Fprintf(w, `var _%s = "`, ast.funcName)
 	if err != nil {
 		return
@@ -214,13 +280,17 @@ func nocompressNomemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
 		return
 	}
 
+	if c.AdaptiveCompression {
+		return writeAdaptiveFunc(w, ast, `"`)
+	}
+
 	_, err = fmt.Fprintf(w, tmplFuncNocompressNomemcopy, ast.funcName,
 		ast.funcName, ast.name)
 
 	return
 }
 
-func nocompressMemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
+func nocompressMemcopy(w io.Writer, c *Config, ast *asset, r io.Reader) (err error) {
 	_, err = fmt.Fprintf(w, `var _%s = []byte(`, ast.funcName)
 	if err != nil {
 		return
@@ -240,6 +310,10 @@ func nocompressMemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
 		return
 	}
 
+	if c.AdaptiveCompression {
+		return writeAdaptiveFunc(w, ast, `)`)
+	}
+
 	_, err = fmt.Fprintf(w, tmplFuncNocompressMemcopy, ast.funcName,
 		ast.funcName)
 
@@ -247,7 +321,7 @@ func nocompressMemcopy(w io.Writer, ast *asset, r io.Reader) (err error) {
 }
 
 // nolint: gas
-func assetReleaseCommon(w io.Writer, c *Config, ast *asset) (err error) {
+func assetReleaseCommon(w io.Writer, c *Config, ast *asset, hashes *assetHashes) (err error) {
 	fi, err := os.Stat(ast.path)
 	if err != nil {
 		return err
@@ -256,6 +330,9 @@ func assetReleaseCommon(w io.Writer, c *Config, ast *asset) (err error) {
 	mode := uint(fi.Mode())
 	modTime := fi.ModTime().Unix()
 	size := fi.Size()
+	if ast.content != nil {
+		size = int64(len(ast.content))
+	}
 	if c.NoMetadata {
 		mode = 0
 		modTime = 0
@@ -268,24 +345,28 @@ func assetReleaseCommon(w io.Writer, c *Config, ast *asset) (err error) {
 		modTime = c.ModTime
 	}
 
-	var md5checksum string
-	if c.MD5Checksum {
-		var buf []byte
-
-		buf, err = ioutil.ReadFile(ast.path)
+	var fields string
+	if c.ContentType {
+		ctype, err := detectContentType(c, ast)
 		if err != nil {
 			return err
 		}
-
-		h := md5.New()
-		if _, err = h.Write(buf); err != nil {
+		fields += fmt.Sprintf("\n\tcontentType: %q,", ctype)
+	}
+	if c.withMeta() {
+		meta, err := assetMeta(c, ast)
+		if err != nil {
 			return err
 		}
-		md5checksum = fmt.Sprintf("%x", h.Sum(nil))
+		if meta != nil {
+			fields += "\n\tmeta: " + formatMeta(meta, 1) + ","
+		}
 	}
+	fields += hashes.fields()
 
-	_, err = fmt.Fprintf(w, tmplReleaseCommon, ast.funcName, ast.funcName,
-		ast.name, size, md5checksum, mode, modTime)
+	_, err = fmt.Fprintf(w, tmplReleaseCommon, ast.funcName, ast.name, size,
+		hashes.md5sum(), fields, mode, modTime, ast.funcName,
+		ast.funcName, ast.funcName)
 
 	return err
 }
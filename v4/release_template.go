@@ -12,21 +12,8 @@ import (
 
 `
 
-const tmplImportCompressNomemcopy = `
-import (
-	"bytes"
-	"compress/gzip"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
-)
-
-func bindataRead(data, name string) ([]byte, error) {
-	gz, err := gzip.NewReader(strings.NewReader(data))
+const tmplBindataReadCompressNomemcopy = `func bindataRead(data, name string) ([]byte, error) {
+	gz, err := bindataDecompress(strings.NewReader(data))
 	if err != nil {
 		return nil, fmt.Errorf("Read %q: %v", name, err)
 	}
@@ -47,21 +34,8 @@ func bindataRead(data, name string) ([]byte, error) {
 
 `
 
-const tmplImportCompressMemcopy = `
-import (
-	"bytes"
-	"compress/gzip"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
-)
-
-func bindataRead(data []byte, name string) ([]byte, error) {
-	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+const tmplBindataReadCompressMemcopy = `func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := bindataDecompress(bytes.NewBuffer(data))
 	if err != nil {
 		return nil, fmt.Errorf("Read %q: %v", name, err)
 	}
@@ -82,19 +56,7 @@ func bindataRead(data []byte, name string) ([]byte, error) {
 
 `
 
-const tmplImportNocompressNomemcopy = `
-import (
-	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"reflect"
-	"strings"
-	"time"
-	"unsafe"
-)
-
-// nolint: deadcode, gas
+const tmplBindataReadNocompressNomemcopy = `// nolint: deadcode, gas
 func bindataRead(data, name string) ([]byte, error) {
 	var empty [0]byte
 	sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
@@ -108,18 +70,6 @@ func bindataRead(data, name string) ([]byte, error) {
 
 `
 
-const tmplImportNocompressMemcopy = `
-import (
-	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
-)
-
-`
-
 const tmplReleaseHeader = `
 type asset struct {
 	bytes []byte
@@ -129,7 +79,7 @@ type asset struct {
 type fileInfoEx interface {
 	os.FileInfo
 	MD5Checksum() string
-}
+%s}
 
 type bindataFileInfo struct {
 	name        string
@@ -137,7 +87,7 @@ type bindataFileInfo struct {
 	mode        os.FileMode
 	modTime     time.Time
 	md5checksum string
-}
+%s}
 
 func (fi bindataFileInfo) Name() string {
 	return fi.name
@@ -155,12 +105,9 @@ func (fi bindataFileInfo) MD5Checksum() string {
 	return fi.md5checksum
 }
 func (fi bindataFileInfo) IsDir() bool {
-	return false
-}
-func (fi bindataFileInfo) Sys() interface{} {
-	return nil
+	return fi.mode.IsDir()
 }
-
+%s
 `
 
 const tmplFuncCompressNomemcopy string = `"
@@ -206,21 +153,21 @@ func %sBytes() ([]byte, error) {
 
 const tmplReleaseCommon string = `
 
+var %sInfo = bindataFileInfo{
+	name: %q,
+	size: %d,
+	md5checksum: %q,%s
+	mode: os.FileMode(%d),
+	modTime: time.Unix(%d, 0),
+}
+
 func %s() (*asset, error) {
 	bytes, err := %sBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{
-		name: %q,
-		size: %d,
-		md5checksum: %q,
-		mode: os.FileMode(%d),
-		modTime: time.Unix(%d, 0),
-	}
-
-	a := &asset{bytes: bytes, info: info}
+	a := &asset{bytes: bytes, info: %sInfo}
 
 	return a, nil
 }
@@ -5,19 +5,23 @@
 package bindata
 
 import (
-	"fmt"
 	"io"
 )
 
-func writeRestore(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `
+// writeRestore writes the functions RestoreAsset and RestoreAssets in the
+// scope s.
+func writeRestore(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplRestore+tmplFilePath)
+}
+
+const tmplRestore = `
 // RestoreAsset restores an asset under the given directory
-func RestoreAsset(dir, name string) error {
-	data, err := Asset(name)
+{{.Decl "RestoreAsset"}}(dir, name string) error {
+	data, err := {{.Ref "Asset"}}(name)
 	if err != nil {
 		return err
 	}
-	info, err := AssetInfo(name)
+	info, err := {{.Ref "AssetInfo"}}(name)
 	if err != nil {
 		return err
 	}
@@ -32,27 +36,444 @@ func RestoreAsset(dir, name string) error {
 	return os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
 }
 
-// RestoreAssets restores an asset under the given directory recursively
-func RestoreAssets(dir, name string) error {
-	children, err := AssetDir(name)
+// RestoreAssets restores an asset under the given directory recursively.
+// The directories are recreated with their mode and modification time.
+{{.Decl "RestoreAssets"}}(dir, name string) error {
+	children, err := {{.Ref "AssetDir"}}(name)
 	// File
 	if err != nil {
-		return RestoreAsset(dir, name)
+		return {{.Ref "RestoreAsset"}}(dir, name)
 	}
 	// Dir
+	err = os.MkdirAll(_filePath(dir, name), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
 	for _, child := range children {
-		err = RestoreAssets(dir, filepath.Join(name, child))
+		err = {{.Ref "RestoreAssets"}}(dir, filepath.Join(name, child))
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if len(name) == 0 {
+		return nil
+	}
+	info, err := {{.Ref "AssetInfo"}}(name)
+	if err != nil {
+		return err
+	}
+	// The mode and modification time are set after all children are
+	// restored, since the directory may not be writable and restoring
+	// the children changes its modification time.
+	err = os.Chmod(_filePath(dir, name), info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if info.ModTime().IsZero() {
+		return nil
+	}
+	return os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
 }
+`
 
+const tmplFilePath = `
 func _filePath(dir, name string) string {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
 	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
-`)
+`
+
+// restoreWithOptionsImports contains list of packages required by the
+// generated function RestoreAssetsWithOptions.
+// nolint: gochecknoglobals
+var restoreWithOptionsImports = []string{
+	"context", "crypto/md5", "errors", "fmt", "io/ioutil", "os",
+	"path/filepath", "sort", "strings", "sync",
+}
+
+// writeRestoreWithOptions writes the function RestoreAssetsWithOptions and
+// its options in the scope s.
+func writeRestoreWithOptions(w io.Writer, s genScope) error {
+	return writeScoped(w, s, tmplRestoreWithOptions)
+}
+
+// tmplRestoreWithOptions contains the function RestoreAssetsWithOptions, its
+// options, and the functions that restore the assets.
+const tmplRestoreWithOptions = tmplRestoreOptions + tmplRestoreAssetsWithOptions +
+	tmplRestoreMkdir + tmplRestoreFiles + tmplRestoreMirror
+
+const tmplRestoreOptions = `
+// RestoreOverwrite define the policy when restoring an asset into an
+// existing file.
+type RestoreOverwrite int
+
+// List of policy when restoring an asset into an existing file.
+const (
+	// RestoreReplace replace the existing file.
+	RestoreReplace RestoreOverwrite = iota
+	// RestoreSkip keep the existing file.
+	RestoreSkip
+	// RestoreFail stop restoring and return an error.
+	RestoreFail
+)
+
+// RestoreOptions define the options for RestoreAssetsWithOptions.
+type RestoreOptions struct {
+	// Overwrite define the policy when the file already exist.
+	Overwrite RestoreOverwrite
+
+	// SkipUnchanged keep the existing file if its content has the same
+	// checksum as the asset.
+	// It only affect the RestoreReplace policy.
+	SkipUnchanged bool
+
+	// Mirror remove the files and directories under the restored
+	// directory that are not part of the assets.
+	Mirror bool
+
+	// PermMask contains the permission bits that are cleared from the
+	// mode of restored files and directories, like umask.
+	PermMask os.FileMode
+
+	// Parallel define the number of files written concurrently.
+	// Zero or negative value means one.
+	Parallel int
+}
+
+// RestoreSummary contains the result of RestoreAssetsWithOptions.
+// Written and Skipped contains the name of assets, while Removed contains
+// the name of files and directories, relative to the restore directory,
+// that are removed by the Mirror option.
+// Each list is sorted.
+type RestoreSummary struct {
+	Written []string
+	Skipped []string
+	Removed []string
+}
+`
+
+const tmplRestoreAssetsWithOptions = `
+//
+// RestoreAssetsWithOptions restores an asset under the given directory
+// recursively, using the given options.
+// Each file is written into a temporary file first and then renamed, so the
+// file is never partially written.
+// It refuse to restore the asset whose name contains "..", or whose parent
+// directory is a symbolic link that resolve outside of dir, so no file is
+// written outside the directory.
+// The returned summary contains the files that has been processed, even if
+// an error occured.
+//
+{{.Decl "RestoreAssetsWithOptions"}}(ctx context.Context, dir, name string, opts RestoreOptions) (summary RestoreSummary, err error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if !bindataRestoreValid(cannonicalName) {
+		return summary, &os.PathError{Op: "restore", Path: name, Err: os.ErrInvalid}
+	}
+
+	var (
+		files  []string
+		dirs   []string
+		assets = make(map[string]bool)
+	)
+	err = {{.Ref "AssetWalk"}}(cannonicalName, func(assetName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !bindataRestoreValid(assetName) {
+			return &os.PathError{Op: "restore", Path: assetName, Err: os.ErrInvalid}
+		}
+		if info.IsDir() {
+			dirs = append(dirs, assetName)
+		} else {
+			files = append(files, assetName)
+		}
+		assets[assetName] = true
+		return nil
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	for _, dirName := range dirs {
+		err = bindataRestoreMkdir(dir, dirName)
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	err = {{.Ref "restoreFiles"}}(ctx, dir, files, opts, &summary)
+	if err != nil {
+		return summary, err
+	}
+
+	if opts.Mirror && (len(cannonicalName) == 0 || len(dirs) > 0) {
+		err = bindataRestoreMirror(dir, cannonicalName, assets, &summary)
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	// The mode and modification time of directories are set after all
+	// files are restored, from the deepest one.
+	for x := len(dirs) - 1; x >= 0; x-- {
+		info, err := {{.Ref "AssetInfo"}}(dirs[x])
+		if err != nil {
+			return summary, err
+		}
+		dirPath := _filePath(dir, dirs[x])
+		err = os.Chmod(dirPath, info.Mode().Perm()&^opts.PermMask)
+		if err != nil {
+			return summary, err
+		}
+		if info.ModTime().IsZero() {
+			continue
+		}
+		err = os.Chtimes(dirPath, info.ModTime(), info.ModTime())
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+`
+
+const tmplRestoreMkdir = `
+// bindataRestoreValid return true if name does not contains "..".
+func bindataRestoreValid(name string) bool {
+	for _, p := range strings.Split(name, "/") {
+		if p == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// bindataRestoreMkdir create the directory name under dir, including its
+// parents.
+// It returns an error if the deepest existing path of name resolve outside
+// of dir, for example if one of the sub directory is a symbolic link to
+// another directory.
+func bindataRestoreMkdir(dir, name string) error {
+	err := os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	target := _filePath(dir, name)
+	existing := target
+	for {
+		_, err = os.Lstat(existing)
+		if err == nil {
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		existing = filepath.Dir(existing)
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return &os.PathError{Op: "restore", Path: target, Err: errors.New("outside of directory")}
+	}
+
+	return os.MkdirAll(target, os.FileMode(0755))
+}
+`
+
+const tmplRestoreFiles = `
+// {{.Name "restoreFiles"}} write the files concurrently.
+{{.Decl "restoreFiles"}}(ctx context.Context, dir string, files []string, opts RestoreOptions, summary *RestoreSummary) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		names = make(chan string)
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+	for x := 0; x < parallel; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if ctx.Err() != nil {
+					continue
+				}
+				written, errWrite := {{.Ref "restoreFile"}}(dir, name, opts)
+
+				mu.Lock()
+				if errWrite != nil {
+					if err == nil {
+						err = errWrite
+					}
+					cancel()
+				} else if written {
+					summary.Written = append(summary.Written, name)
+				} else {
+					summary.Skipped = append(summary.Skipped, name)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+loop:
+	for _, name := range files {
+		select {
+		case names <- name:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(names)
+	wg.Wait()
+
+	sort.Strings(summary.Written)
+	sort.Strings(summary.Skipped)
+
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// {{.Name "restoreFile"}} write the asset name into temporary file and rename
+// it, based on the overwrite policy.
+// It returns true if the file is written.
+{{.Decl "restoreFile"}}(dir, name string, opts RestoreOptions) (bool, error) {
+	data, err := {{.Ref "Asset"}}(name)
+	if err != nil {
+		return false, err
+	}
+	info, err := {{.Ref "AssetInfo"}}(name)
+	if err != nil {
+		return false, err
+	}
+
+	err = bindataRestoreMkdir(dir, filepath.Dir(name))
+	if err != nil {
+		return false, err
+	}
+
+	target := _filePath(dir, name)
+
+	fi, err := os.Lstat(target)
+	if err == nil {
+		if fi.IsDir() {
+			return false, &os.PathError{Op: "restore", Path: target, Err: fmt.Errorf("is a directory")}
+		}
+		switch opts.Overwrite {
+		case RestoreSkip:
+			return false, nil
+		case RestoreFail:
+			return false, &os.PathError{Op: "restore", Path: target, Err: os.ErrExist}
+		}
+		if opts.SkipUnchanged {
+			unchanged, err := bindataRestoreUnchanged(target, fi, data, info)
+			if err != nil {
+				return false, err
+			}
+			if unchanged {
+				return false, nil
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return false, err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(info.Mode().Perm() &^ opts.PermMask)
+	}
+	errClose := tmp.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), target)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return false, err
+	}
+	return true, nil
+}
+`
+
+const tmplRestoreMirror = `
+// bindataRestoreUnchanged return true if the MD5 checksum of file content
+// is equal with the asset.
+func bindataRestoreUnchanged(target string, fi os.FileInfo, data []byte, info os.FileInfo) (bool, error) {
+	if fi.Size() != int64(len(data)) {
+		return false, nil
+	}
+
+	var exp string
+	if ex, ok := info.(interface{ MD5Checksum() string }); ok {
+		exp = ex.MD5Checksum()
+	}
+	if len(exp) == 0 {
+		exp = fmt.Sprintf("%x", md5.Sum(data))
+	}
+
+	got, err := ioutil.ReadFile(target)
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprintf("%x", md5.Sum(got)) == exp, nil
+}
+
+// bindataRestoreMirror remove the files and directories under the restored
+// directory that are not part of the assets.
+func bindataRestoreMirror(dir, name string, assets map[string]bool, summary *RestoreSummary) error {
+	root := _filePath(dir, name)
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if assets[rel] {
+			return nil
+		}
+		err = os.RemoveAll(p)
+		if err != nil {
+			return err
+		}
+		summary.Removed = append(summary.Removed, rel)
+		if fi.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	sort.Strings(summary.Removed)
 	return err
 }
+`
@@ -0,0 +1,212 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// nolint: gochecknoglobals
+var (
+	// cssURLPattern match the url() function in CSS, where the reference
+	// is in one of the first three sub-matches.
+	cssURLPattern = regexp.MustCompile(
+		`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)`)
+
+	// htmlTagPattern match the comment opening, or the start tag in HTML,
+	// where the tag name is in the first sub-match.
+	htmlTagPattern = regexp.MustCompile(
+		`<(?:!--|([a-zA-Z][a-zA-Z0-9-]*)(?:[^>"']|"[^"]*"|'[^']*')*>)`)
+
+	// htmlAttrPattern match the src and href attributes inside the HTML
+	// start tag, where the reference is in one of the first three
+	// sub-matches.
+	// The attribute must be preceded by white space, so the attribute
+	// like data-src or xlink:href does not match.
+	htmlAttrPattern = regexp.MustCompile(
+		`(?i)\s(?:src|href)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// isRewritable return true if the references inside the asset are
+// rewritten to the hashed names, based on its file extension.
+func isRewritable(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".css", ".htm", ".html":
+		return true
+	}
+	return false
+}
+
+// rewrite read the content of CSS or HTML asset and replace the relative
+// references to other assets with their hashed names.
+// The rewritten content is stored in the asset only if its changed.
+//
+// The references inside CSS url() are rewritten in both CSS and HTML, while
+// the src and href attributes are rewritten in the HTML start tags only.
+func (hn *hashNamer) rewrite(ast *asset) (err error) {
+	in, err := ioutil.ReadFile(ast.path)
+	if err != nil {
+		return err
+	}
+
+	out := in
+
+	if strings.ToLower(path.Ext(ast.name)) != ".css" {
+		out, err = hn.rewriteRefs(ast, htmlAttrMatches(out), out)
+		if err != nil {
+			return err
+		}
+	}
+	out, err = hn.rewriteRefs(ast, cssURLPattern.FindAllSubmatchIndex(out, -1), out)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(in, out) {
+		ast.content = out
+	}
+	return nil
+}
+
+// htmlAttrMatches return the sub-match indexes of htmlAttrPattern inside the
+// start tags in HTML content.
+// The comments, and the content of script and style elements, are skipped.
+func htmlAttrMatches(content []byte) (matches [][]int) {
+	off := 0
+	for {
+		base := off
+		loc := htmlTagPattern.FindSubmatchIndex(content[base:])
+		if loc == nil {
+			break
+		}
+		start, end := base+loc[0], base+loc[1]
+		off = end
+
+		if loc[2] < 0 {
+			x := bytes.Index(content[end:], []byte("-->"))
+			if x < 0 {
+				break
+			}
+			off = end + x + len("-->")
+			continue
+		}
+		tagName := strings.ToLower(string(content[base+loc[2] : base+loc[3]]))
+
+		for _, match := range htmlAttrPattern.FindAllSubmatchIndex(content[start:end], -1) {
+			for x := range match {
+				if match[x] >= 0 {
+					match[x] += start
+				}
+			}
+			matches = append(matches, match)
+		}
+
+		if tagName != "script" && tagName != "style" {
+			continue
+		}
+		x := bytes.Index(bytes.ToLower(content[end:]), []byte("</"+tagName))
+		if x < 0 {
+			break
+		}
+		off = end + x
+	}
+	return matches
+}
+
+// rewriteRefs replace each reference in the sub-matches of content.
+func (hn *hashNamer) rewriteRefs(ast *asset, matches [][]int, content []byte) (out []byte, err error) {
+	var last int
+
+	for _, match := range matches {
+		for x := 2; x < len(match); x += 2 {
+			start, end := match[x], match[x+1]
+			if start < 0 {
+				continue
+			}
+
+			ref, err := hn.resolve(ast, string(content[start:end]))
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, content[last:start]...)
+			out = append(out, ref...)
+			last = end
+			break
+		}
+	}
+	out = append(out, content[last:]...)
+
+	return out, nil
+}
+
+// resolve return the reference with its path replaced by the hashed name of
+// the asset that it refers to.
+// The reference that is not relative, or refers to directory, is returned
+// as is.
+// If the reference could not be resolved to an asset, a warning is printed
+// and the reference is returned as is.
+func (hn *hashNamer) resolve(ast *asset, ref string) (string, error) {
+	if !isRelativeRef(ref) {
+		return ref, nil
+	}
+
+	refPath, suffix := ref, ""
+	if x := strings.IndexAny(ref, "?#"); x >= 0 {
+		refPath, suffix = ref[:x], ref[x:]
+	}
+	if strings.HasSuffix(refPath, "/") {
+		return ref, nil
+	}
+
+	name, err := url.PathUnescape(refPath)
+	if err != nil {
+		name = refPath
+	}
+	name = path.Join(path.Dir(ast.name), name)
+
+	target, ok := hn.names[name]
+	if !ok {
+		fmt.Fprintf(hn.warn, "warning: %s: unresolved reference %q\n",
+			ast.path, ref)
+		return ref, nil
+	}
+	if hn.visiting[target] {
+		fmt.Fprintf(hn.warn, "warning: %s: circular reference %q\n",
+			ast.path, ref)
+		return ref, nil
+	}
+
+	sum, err := hn.sum(target)
+	if err != nil {
+		return "", err
+	}
+
+	return hashedName(refPath, sum[:hashedNameLen]) + suffix, nil
+}
+
+// isRelativeRef return true if the reference is a relative URL path, not
+// an absolute path, a fragment, an URL with scheme like "https:" or "data:",
+// or a template action.
+func isRelativeRef(ref string) bool {
+	if len(ref) == 0 {
+		return false
+	}
+	switch ref[0] {
+	case '/', '#', '?':
+		return false
+	}
+	if strings.Contains(ref, "{{") {
+		return false
+	}
+	x := strings.IndexAny(ref, ":/?#")
+	return x < 0 || ref[x] != ':'
+}
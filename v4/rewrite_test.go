@@ -0,0 +1,119 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestHashNamerRewrite(t *testing.T) {
+	cfg := &Config{
+		HashedNames:        true,
+		RewriteHashedNames: true,
+	}
+	names := []string{
+		"css/main.css",
+		"css/theme.css",
+		"img/bg.svg",
+		"img/logo.svg",
+		"index.html",
+		"js/app.js",
+	}
+	toc := make(map[string]*asset, len(names))
+	for _, name := range names {
+		toc[name] = &asset{
+			path: "testdata/rewrite/" + name,
+			name: name,
+		}
+	}
+
+	var warn bytes.Buffer
+
+	hn := newHashNamer(cfg, names, toc)
+	hn.warn = &warn
+
+	for _, name := range names {
+		_, err := hn.sum(toc[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileSum := func(name string) string {
+		b := readFile(t, "testdata/rewrite/"+name)
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(b)))[:hashedNameLen]
+	}
+
+	expCSS := strings.NewReplacer(
+		`url("theme.css")`,
+		`url("theme.`+fileSum("css/theme.css")+`.css")`,
+		`url('../img/bg.svg')`,
+		`url('../img/bg.`+fileSum("img/bg.svg")+`.svg')`,
+		`url(../img/logo.svg#icon)`,
+		`url(../img/logo.`+fileSum("img/logo.svg")+`.svg#icon)`,
+	).Replace(readFile(t, "testdata/rewrite/css/main.css"))
+
+	assert(t, expCSS, string(toc["css/main.css"].content), true)
+
+	cssSum := fmt.Sprintf("%x", sha256.Sum256([]byte(expCSS)))[:hashedNameLen]
+	assert(t, "css/main."+cssSum+".css", toc["css/main.css"].hashedName, true)
+
+	expHTML := strings.NewReplacer(
+		`href="css/main.css"`,
+		`href="css/main.`+cssSum+`.css"`,
+		`href='img/logo.svg'`,
+		`href='img/logo.`+fileSum("img/logo.svg")+`.svg'`,
+		`src=js/app.js?v=1`,
+		`src=js/app.`+fileSum("js/app.js")+`.js?v=1`,
+		`url(img/bg.svg)`,
+		`url(img/bg.`+fileSum("img/bg.svg")+`.svg)`,
+	).Replace(readFile(t, "testdata/rewrite/index.html"))
+
+	assert(t, expHTML, string(toc["index.html"].content), true)
+
+	// The asset that does not contain any reference is not rewritten.
+	assert(t, []byte(nil), toc["css/theme.css"].content, true)
+
+	expWarn := "warning: testdata/rewrite/index.html: unresolved reference \"img/missing.png\"\n"
+
+	assert(t, expWarn, warn.String(), true)
+}
+
+func TestIsRelativeRef(t *testing.T) {
+	tests := []struct {
+		ref string
+		exp bool
+	}{
+		{ref: "", exp: false},
+		{ref: "/css/main.css", exp: false},
+		{ref: "#top", exp: false},
+		{ref: "?page=2", exp: false},
+		{ref: "https://example.com/app.js", exp: false},
+		{ref: "data:image/gif;base64,R0lGODlh", exp: false},
+		{ref: "{{ .Logo }}", exp: false},
+		{ref: "app.js", exp: true},
+		{ref: "../img/logo.svg", exp: true},
+		{ref: "img/a:b.png", exp: true},
+	}
+
+	for _, test := range tests {
+		t.Log(test.ref)
+
+		assert(t, test.exp, isRelativeRef(test.ref), true)
+	}
+}
+
+func readFile(t *testing.T, file string) string {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// genScope define where the generated functions live: at the package level,
+// reading the package-level tables, or as the methods of Bundle, reading
+// the fields of bundle.
+// The same template is written in both scopes, using the methods of
+// genScope to name the functions and the tables,
+//
+//	{{.Decl "archiveOpen"}}(name string) ...
+//	rc, info, err := {{.Ref "assetOpen"}}(name)
+//	f, ok := {{.Field "files"}}[name]
+//
+// which are written as
+//
+//	func bindataArchiveOpen(name string) ...
+//	rc, info, err := bindataAssetOpen(name)
+//	f, ok := _bindata[name]
+//
+// in package scope, and as
+//
+//	func (b *Bundle) archiveOpen(name string) ...
+//	rc, info, err := b.assetOpen(name)
+//	f, ok := b.files[name]
+//
+// in bundle scope.
+type genScope struct {
+	// bundle is the name of bundle value, or empty for package scope.
+	bundle string
+
+	// hooks contains the optional parts of template, by their name.
+	hooks map[string]string
+}
+
+// packageFields map the field of Bundle to the package-level table or
+// state that hold the same value.
+// nolint: gochecknoglobals
+var packageFields = map[string]string{
+	"files":     "_bindata",
+	"tree":      "_bintree",
+	"dirs":      "_bindataDirs",
+	"raw":       "_bindataRaw",
+	"hashed":    "_bindataHashedNames",
+	"original":  "_bindataOriginalNames",
+	"manifest":  "_bindataManifest",
+	"signature": "_bindataSignature",
+	"signTOC":   "_bindataSignTOC",
+	"cache":     "AssetCache",
+	"overlay":   "_bindataOverlay",
+	"key":       "_bindataKey",
+}
+
+// newScope return the scope of the generated functions for c.
+func newScope(c *Config) genScope {
+	return genScope{bundle: c.Bundle}
+}
+
+// withHooks return the copy of scope with the optional parts of template.
+func (s genScope) withHooks(hooks map[string]string) genScope {
+	s.hooks = hooks
+	return s
+}
+
+// Bundle return true if the functions are written as the methods of Bundle.
+func (s genScope) Bundle() bool {
+	return len(s.bundle) > 0
+}
+
+// Name return the name of function.
+// The unexported name is prefixed with "bindata" in package scope.
+func (s genScope) Name(name string) string {
+	if s.Bundle() || isExported(name) {
+		return name
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return "bindata" + string(unicode.ToUpper(r)) + name[size:]
+}
+
+// Decl return the declaration of function, up to its parameters.
+func (s genScope) Decl(name string) string {
+	if s.Bundle() {
+		return "func (b *Bundle) " + name
+	}
+	return "func " + s.Name(name)
+}
+
+// Ref return the expression that call the function.
+func (s genScope) Ref(name string) string {
+	if s.Bundle() {
+		return "b." + name
+	}
+	return s.Name(name)
+}
+
+// Call return the expression that call the function from the method of
+// helper type, where recv is the receiver that hold the bundle in its field
+// b.
+func (s genScope) Call(recv, name string) string {
+	if s.Bundle() {
+		return recv + ".b." + name
+	}
+	return s.Name(name)
+}
+
+// Field return the expression that read the field of Bundle, or the
+// package-level variable that hold the same value.
+func (s genScope) Field(name string) string {
+	if s.Bundle() {
+		return "b." + name
+	}
+	return packageFields[name]
+}
+
+// Hook return the optional part of template, written in the same scope.
+func (s genScope) Hook(name string) (string, error) {
+	text, ok := s.hooks[name]
+	if !ok || len(text) == 0 {
+		return "", nil
+	}
+	return scoped(s, text)
+}
+
+// isExported return true if name begin with upper case letter.
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
+
+// scoped return the template tmpl written in the scope s.
+func scoped(s genScope, tmpl string) (string, error) {
+	var sb strings.Builder
+	err := writeScoped(&sb, s, tmpl)
+	return sb.String(), err
+}
+
+// writeScoped writes the template tmpl into w, in the given scope.
+func writeScoped(w io.Writer, s genScope, tmpl string) (err error) {
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, s)
+}
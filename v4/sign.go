@@ -0,0 +1,390 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// List of file names that are written by SignDir into the signed directory.
+const (
+	signManifestName  = "bindata.manifest"
+	signSignatureName = "bindata.manifest.sig"
+)
+
+// signImports contains list of packages required by the generated function
+// VerifySignature and VerifySignatureDir.
+// nolint: gochecknoglobals
+var signImports = []string{
+	"crypto/ed25519", "crypto/sha256", "encoding/hex", "fmt", "io",
+	"io/ioutil", "os", "path/filepath", "strings",
+}
+
+// addSignImports add the packages required by the generated function
+// VerifySignature and VerifySignatureDir, and the reader of embedded data.
+func addSignImports(c *Config, imports goImports) {
+	imports.add(signImports...)
+	if c.Debug || c.Dev {
+		return
+	}
+	if c.NoMemCopy && len(c.EncryptKey) == 0 {
+		imports.add("strings")
+	} else {
+		imports.add("bytes")
+	}
+}
+
+// ParseSignKey parse the ed25519 private key from PEM encoded PKCS #8, as
+// generated by "openssl genpkey -algorithm ed25519".
+func ParseSignKey(b []byte) (key ed25519.PrivateKey, err error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%w: missing PEM block", ErrSignKey)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSignKey, err)
+	}
+
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not ed25519 key", ErrSignKey, parsed)
+	}
+	return key, nil
+}
+
+// SignDir sign the content of all files inside the directory dir, for
+// example an overlay directory, with the ed25519 private key, so it can be
+// verified by the generated function VerifySignatureDir.
+//
+// The manifest, which contains the SHA-256 checksum and name of each file,
+// is written into the file "bindata.manifest" in dir, and its hex encoded
+// signature into the file "bindata.manifest.sig".
+// The file that is not regular, like symbolic link, is not signed, so its
+// rejected by VerifySignatureDir.
+func SignDir(key ed25519.PrivateKey, dir string) (err error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return ErrSignKey
+	}
+
+	sums := make(map[string]string)
+
+	err = filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if name == signManifestName || name == signSignatureName {
+			return nil
+		}
+
+		h := sha256.New()
+
+		err = hashFile(h, path)
+		if err != nil {
+			return err
+		}
+
+		sums[name] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	manifest := formatSignManifest(sums)
+	sig := hex.EncodeToString(ed25519.Sign(key, manifest))
+
+	err = ioutil.WriteFile(filepath.Join(dir, signManifestName), manifest, 0644)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, signSignatureName),
+		[]byte(sig+"\n"), 0644)
+}
+
+// formatSignManifest return the manifest that is signed, which contains one
+// line for each asset, sorted by name, in the same format as the output of
+// sha256sum: the hex encoded checksum, two spaces, and the name.
+func formatSignManifest(sums map[string]string) []byte {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(sums[name] + "  " + name + "\n")
+	}
+	return []byte(sb.String())
+}
+
+// writeSignature writes the signed manifest of assets, its signature, and
+// the functions VerifySignature and VerifySignatureDir.
+func writeSignature(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	err = writeSignManifest(w, c, "", keys, toc)
+	if err != nil {
+		return err
+	}
+	return writeVerifySignature(w, c, genScope{})
+}
+
+// writeVerifySignature writes the functions VerifySignature and
+// VerifySignatureDir in the scope s.
+// The manifest and its signature of bundle are written along with the
+// tables of each bundle.
+func writeVerifySignature(w io.Writer, c *Config, s genScope) (err error) {
+	tmpl := tmplVerifySignature + fmt.Sprintf(tmplVerifySignatureDir,
+		signManifestName, signSignatureName, signManifestName,
+		signSignatureName)
+	err = writeScoped(w, s, tmpl)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, tmplVerifyManifest)
+	if err != nil {
+		return err
+	}
+	return writeSignHash(w, c, s)
+}
+
+// writeSignManifest writes the manifest of assets and its signature as
+// constants, whose names are suffixed with the bundle name.
+func writeSignManifest(w io.Writer, c *Config, bundle string, keys []string, toc map[string]*asset) (err error) {
+	sums := make(map[string]string, len(keys))
+	for _, key := range keys {
+		ast := toc[key]
+		sums[ast.name] = ast.sum
+	}
+
+	manifest := formatSignManifest(sums)
+	sig := ed25519.Sign(c.SignKey, manifest)
+
+	_, err = fmt.Fprintf(w, tmplSignManifest, bundle, bundle)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(manifest), "\n")
+	for _, line := range lines[:len(lines)-1] {
+		_, err = fmt.Fprintf(w, " +\n\t%q", line)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, tmplSignature, bundle, bundle, bundle,
+		hex.EncodeToString(sig))
+	return err
+}
+
+// writeSignHash writes the function signHash in the scope s, that read the
+// content of asset for VerifySignature.
+// In release mode, the content is read from the embedded data, while in
+// debug mode, its read using the asset function before its replaced by the
+// overlay.
+func writeSignHash(w io.Writer, c *Config, s genScope) (err error) {
+	if c.Debug || c.Dev {
+		// The asset functions are copied by the wrap function.
+		if !s.Bundle() {
+			_, err = io.WriteString(w, tmplSignTOC)
+			if err != nil {
+				return err
+			}
+		}
+		return writeScoped(w, s, tmplSignHashDebug)
+	}
+
+	hooks := map[string]string{
+		"reader": verifyRawReader(c, `{{.Field "key"}}.decrypt`),
+	}
+	return writeScoped(w, s.withHooks(hooks), tmplSignHash)
+}
+
+const tmplSignManifest = `
+//
+// _bindataManifest%s contains the SHA-256 checksum and name of each asset, that
+// is signed when the code is generated.
+//
+const _bindataManifest%s = ""`
+
+const tmplSignature = `
+
+// _bindataSignature%s contains the hex encoded ed25519 signature of
+// _bindataManifest%s.
+const _bindataSignature%s = %q
+`
+
+const tmplVerifySignature = `
+//
+// VerifySignature verify that the manifest of embedded assets is signed by
+// the private key of pub, and that the content of each asset match its
+// SHA-256 checksum in the manifest.
+// The content of asset is read from the embedded data, so the asset that is
+// overridden by the overlay directory, or stored in the cache, is not
+// verified; use VerifySignatureDir to verify the overlay directory.
+//
+{{.Decl "VerifySignature"}}(pub ed25519.PublicKey) error {
+	_, err := bindataVerifyManifest(pub, []byte({{.Field "manifest"}}),
+		[]byte({{.Field "signature"}}), {{.Ref "signHash"}})
+	return err
+}
+`
+
+const tmplVerifySignatureDir = `
+//
+// VerifySignatureDir verify the files in directory dir, for example the
+// overlay directory, that has been signed by "go-bindata sign".
+// It verify that the manifest in dir is signed by the private key of pub,
+// that the content of each file match its SHA-256 checksum in the
+// manifest, and that there is no other file in dir, except the manifest
+// and its signature.
+//
+{{.Decl "VerifySignatureDir"}}(pub ed25519.PublicKey, dir string) error {
+	manifest, err := ioutil.ReadFile(filepath.Join(dir, %q))
+	if err != nil {
+		return err
+	}
+	sig, err := ioutil.ReadFile(filepath.Join(dir, %q))
+	if err != nil {
+		return err
+	}
+	names, err := bindataVerifyManifest(pub, manifest, sig, func(name string, w io.Writer) error {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The file that is not in the manifest, including the symbolic
+	// link, is rejected, since it may be read from the overlay
+	// directory.
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if names[name] || name == %q || name == %q {
+			return nil
+		}
+		return fmt.Errorf("VerifySignature: %%s is not in the signed manifest", name)
+	})
+}
+`
+
+const tmplVerifyManifest = `
+// bindataVerifyManifest verify the hex encoded signature of manifest, and
+// the content of each file in the manifest that is written by hash.
+// It returns the names of files in the manifest.
+func bindataVerifyManifest(pub ed25519.PublicKey, manifest, sig []byte, hash func(name string, w io.Writer) error) (map[string]bool, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("VerifySignature: invalid public key size %d", len(pub))
+	}
+	rawSig, err := hex.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return nil, fmt.Errorf("VerifySignature: invalid signature: %v", err)
+	}
+	if !ed25519.Verify(pub, manifest, rawSig) {
+		return nil, fmt.Errorf("VerifySignature: manifest is not signed by the public key")
+	}
+
+	names := make(map[string]bool)
+	for _, line := range strings.Split(string(manifest), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		fields := strings.SplitN(line, "  ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("VerifySignature: invalid manifest line %q", line)
+		}
+		h := sha256.New()
+		err = hash(fields[1], h)
+		if err != nil {
+			return nil, fmt.Errorf("VerifySignature: %v", err)
+		}
+		if hex.EncodeToString(h.Sum(nil)) != fields[0] {
+			return nil, fmt.Errorf("VerifySignature: %s does not match the signed manifest", fields[1])
+		}
+		names[fields[1]] = true
+	}
+	return names, nil
+}
+`
+
+const tmplSignTOC = `
+// _bindataSignTOC contains the asset functions as they are generated,
+// before they are replaced by the overlay, so VerifySignature read the
+// asset that is embedded.
+// nolint: gochecknoglobals
+var _bindataSignTOC map[string]func() (*asset, error)
+`
+
+const tmplSignHashDebug = `
+// {{.Name "signHash"}} write the content of asset name into w, using the
+// asset function before its replaced by the overlay.
+{{.Decl "signHash"}}(name string, w io.Writer) error {
+	f, ok := {{.Field "signTOC"}}[name]
+	if !ok {
+		return &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	a, err := f()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(a.bytes)
+	return err
+}
+`
+
+const tmplSignHash = `
+// {{.Name "signHash"}} write the content of asset name, that is read from
+// its embedded data, into w.
+{{.Decl "signHash"}}(name string, w io.Writer) error {
+	raw, ok := {{.Field "raw"}}[name]
+	if !ok {
+		return &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+{{.Hook "reader"}}
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("asset %s can't read by error: %v", name, err)
+	}
+	return nil
+}
+`
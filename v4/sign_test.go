@@ -0,0 +1,94 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSignKey(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/sign/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ParseSignKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(t, ed25519.PrivateKeySize, len(key), true)
+
+	_, err = ParseSignKey([]byte("not a key"))
+	assert(t, true, errors.Is(err, ErrSignKey), true)
+}
+
+func TestSignDir(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/sign/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseSignKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "bindata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.Mkdir(filepath.Join(dir, "css"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "css", "main.css"), []byte("body {}\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing the directory twice must produce the same manifest, which
+	// does not contain the manifest and signature files.
+	for x := 0; x < 2; x++ {
+		err = SignDir(key, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest, err := ioutil.ReadFile(filepath.Join(dir, signManifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "a06fd750de7374983daf40016564b1fb6f2168ed2c5742ccf69912e8574803c0  css/main.css\n" +
+		"b53a55383d2f1f040ab010606d7911907f1a17f979f1d475fb4ac226243135e5  index.html\n"
+	assert(t, exp, string(manifest), true)
+
+	sig, err := ioutil.ReadFile(filepath.Join(dir, signSignatureName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawSig, err := hex.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub := key.Public().(ed25519.PublicKey)
+	assert(t, true, ed25519.Verify(pub, manifest, rawSig), true)
+
+	err = SignDir(key[:16], dir)
+	assert(t, ErrSignKey, err, true)
+}
@@ -4,17 +4,19 @@
 
 package bindata
 
-const tmplTypeBintree string = `
+const tmplBintree string = `
 type bintree struct {
 	Func     func() (*asset, error)
 	Children map[string]*bintree
 }
+`
 
+const tmplTypeBintree string = tmplBintree + `
 var _bintree = &bintree`
 
 const tmplBinTreeValues string = `{Func: %s, Children: map[string]*bintree{`
 
-const tmplFuncAssetDir string = `
+const tmplFuncAssetDirDoc string = `
 //
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
@@ -29,64 +31,56 @@ const tmplFuncAssetDir string = `
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
 // AssetDir("") will return []string{"data"}.
+// The returned names are sorted.
 //
-func AssetDir(name string) ([]string, error) {
-	node := _bintree
-	if len(name) != 0 {
-		cannonicalName := strings.Replace(name, "\\", "/", -1)
-		pathList := strings.Split(cannonicalName, "/")
-		for _, p := range pathList {
-			node = node.Children[p]
-			if node == nil {
-				return nil, &os.PathError{
-					Op: "open",
-					Path: name,
-					Err: os.ErrNotExist,
-				}
-			}
-		}
-	}
-	if node.Func != nil {
-		return nil, &os.PathError{
-			Op: "open",
-			Path: name,
-			Err: os.ErrNotExist,
-		}
+`
+
+const tmplFuncAssetDir string = tmplFuncAssetDirDoc + `{{.Decl "AssetDir"}}(name string) ([]string, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	node := {{.Ref "node"}}(cannonicalName)
+	if node == nil || node.Func != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
 	}
 	rv := make([]string, 0, len(node.Children))
 	for childName := range node.Children {
 		rv = append(rv, childName)
 	}
+	sort.Strings(rv)
 	return rv, nil
 }
-
 `
 
+// tmplFuncAsset contains the function Asset, MustAsset, AssetInfo, and
+// AssetNames.
+// The lookup of hashed name and overlay is inserted by the hooks "asset" and
+// "assetInfo", after the embedded asset is not found.
 const tmplFuncAsset string = `
 //
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
 //
-func Asset(name string) ([]byte, error) {
+{{.Decl "Asset"}}(name string) ([]byte, error) {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
+	if f, ok := {{.Field "files"}}[cannonicalName]; ok {
 		a, err := f()
 		if err != nil {
-			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+			return nil, fmt.Errorf("Asset %s can't read by error: %w", name, err)
 		}
 		return a.bytes, nil
 	}
-	return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+{{.Hook "asset"}}	return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
 }
 
 //
 // MustAsset is like Asset but panics when Asset would return an error.
 // It simplifies safe initialization of global variables.
+{{- if not .Bundle}}
 // nolint: deadcode
+{{- end}}
 //
-func MustAsset(name string) []byte {
-	a, err := Asset(name)
+{{.Decl "MustAsset"}}(name string) []byte {
+	a, err := {{.Ref "Asset"}}(name)
 	if err != nil {
 		panic("asset: Asset(" + name + "): " + err.Error())
 	}
@@ -96,32 +90,41 @@ func MustAsset(name string) []byte {
 
 //
 // AssetInfo loads and returns the asset info for the given name.
+// The name can be an asset or a directory.
 // It returns an error if the asset could not be found or could not be loaded.
 //
-func AssetInfo(name string) (os.FileInfo, error) {
+{{.Decl "AssetInfo"}}(name string) (os.FileInfo, error) {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
+	if f, ok := {{.Field "files"}}[cannonicalName]; ok {
 		a, err := f()
 		if err != nil {
-			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %w", name, err)
 		}
 		return a.info, nil
 	}
+{{.Hook "assetInfo"}}	if node := {{.Ref "node"}}(cannonicalName); node != nil {
+		return {{.Ref "dir"}}(cannonicalName)
+	}
 	return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
 }
 
 //
-// AssetNames returns the names of the assets.
+// AssetNames returns the sorted names of the assets.
+{{- if not .Bundle}}
 // nolint: deadcode
+{{- end}}
 //
-func AssetNames() []string {
-	names := make([]string, 0, len(_bindata))
-	for name := range _bindata {
+{{.Decl "AssetNames"}}() []string {
+	names := make([]string, 0, len({{.Field "files"}}))
+	for name := range {{.Field "files"}} {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
+`
 
+const tmplFilesTable string = `
 //
 // _bindata is a table, holding each asset generator, mapped to its name.
 //
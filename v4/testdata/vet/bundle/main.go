@@ -0,0 +1,12 @@
+package main
+
+import (
+	bindata "github.com/shuLhan/go-bindata/v4/internal/tests/withBundle"
+)
+
+func main() {
+	_, _ = bindata.WebAssets.Asset("in/b/test.asset")
+	_, _ = bindata.DocAssets.Asset("in/b/test.asset")
+	_, _ = bindata.DocAssets.AssetDir("in/a")
+	_, _ = bindata.Asset("in/test.asset")
+}
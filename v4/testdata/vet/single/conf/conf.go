@@ -0,0 +1,5 @@
+package conf
+
+// AssetNameInTestAsset has the same name as the generated constant, but its
+// not the constant of generated package.
+const AssetNameInTestAsset = "in/x"
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	bindata "github.com/shuLhan/go-bindata/v4/internal/tests/withNameConsts"
+)
+
+const (
+	dirIn  = "in"
+	nameA  = dirIn + "/a/test.asset"
+	nameNo = dirIn + "/x"
+)
+
+func main() {
+	_, _ = bindata.Asset(bindata.AssetNameInFilename)
+	_, _ = bindata.Asset(nameA)
+	_ = bindata.MustAsset(nameNo)
+	_, _ = bindata.AssetInfo("in/c")
+	_, _ = bindata.AssetDir("in/b")
+	_, _ = bindata.AssetDir("in/file name")
+	_, _ = bindata.Asset(os.Args[1])
+	fmt.Println("done")
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+)
+
+// store has the methods with the same name as the generated functions, which
+// must not be checked.
+type store struct{}
+
+func (store) Asset(name string) ([]byte, error) {
+	return nil, nil
+}
+
+func (store) AssetDir(name string) ([]string, error) {
+	return nil, nil
+}
+
+func init() {
+	var s store
+	_, _ = s.Asset("in/y")
+	_, _ = store{}.AssetDir("in/z")
+	_, _ = s.Asset(os.Args[1])
+}
@@ -0,0 +1,11 @@
+package sub
+
+// Asset is not the generated function, since the package is not the
+// generated package.
+func Asset(name string) ([]byte, error) {
+	return nil, nil
+}
+
+func init() {
+	_, _ = Asset("in/y")
+}
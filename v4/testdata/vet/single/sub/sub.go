@@ -0,0 +1,12 @@
+package sub
+
+import (
+	bindata "github.com/shuLhan/go-bindata/v4/internal/tests/withNameConsts"
+	"github.com/shuLhan/go-bindata/v4/testdata/vet/single/conf"
+)
+
+func init() {
+	_, _ = bindata.AssetReader(`in\c\test.asset`)
+	_, _ = bindata.AssetInfo("in/d")
+	_, _ = bindata.Asset(conf.AssetNameInTestAsset)
+}
@@ -38,6 +38,13 @@ func (root *assetTree) Add(route []string, ast *asset) {
 	root.asset = ast
 }
 
+// AddDir add the directory node into the tree, including its parents.
+func (root *assetTree) AddDir(route []string) {
+	for _, name := range route {
+		root = root.child(name)
+	}
+}
+
 func ident(w io.Writer, n int) (err error) {
 	for i := 0; i < n; i++ {
 		_, err = w.Write([]byte{'\t'})
@@ -125,19 +132,36 @@ func (root *assetTree) WriteAsGoMap(w io.Writer) (err error) {
 	return root.writeGoMap(w, 0)
 }
 
-func writeTOCTree(w io.Writer, keys []string, toc map[string]*asset) error {
-	_, err := fmt.Fprint(w, tmplFuncAssetDir)
+func writeTOCTree(w io.Writer, c *Config, keys []string, toc map[string]*asset, dirs []*asset) error {
+	err := writeAssetDir(w, c, genScope{})
 	if err != nil {
 		return err
 	}
+
+	return newTOCTree(keys, toc, dirs).WriteAsGoMap(w)
+}
+
+// writeAssetDir writes the function AssetDir in the scope s, which merge the
+// file names from the overlay directory, if its set.
+func writeAssetDir(w io.Writer, c *Config, s genScope) error {
+	if c.Overlay {
+		return writeScoped(w, s, tmplFuncAssetDirOverlay)
+	}
+	return writeScoped(w, s, tmplFuncAssetDir)
+}
+
+// newTOCTree create the tree of assets and directories.
+func newTOCTree(keys []string, toc map[string]*asset, dirs []*asset) *assetTree {
 	tree := newAssetTree()
 	for _, key := range keys {
 		ast := toc[key]
 		pathList := strings.Split(ast.name, "/")
 		tree.Add(pathList, ast)
 	}
-
-	return tree.WriteAsGoMap(w)
+	for _, dir := range dirs {
+		tree.AddDir(strings.Split(dir.name, "/"))
+	}
+	return tree
 }
 
 // getLongestAssetNameLen will return length of the longest asset name in toc.
@@ -151,9 +175,34 @@ func getLongestAssetNameLen(keys []string) (longest int) {
 	return longest
 }
 
+// lookupHooks return the lookup of hashed name and overlay directory, which
+// are inserted into the functions that read the asset by its name, after
+// the embedded asset is not found.
+func lookupHooks(c *Config) map[string]string {
+	hooks := make(map[string]string)
+	if c.HashedNames {
+		hooks["asset"] = tmplHashedNameAsset
+		hooks["assetInfo"] = tmplHashedNameAssetInfo
+		hooks["rawOpen"] = tmplHashedNameRawOpen
+		hooks["exist"] = tmplHashedNameExist
+	}
+	if c.Overlay {
+		hooks["asset"] += tmplOverlayAsset
+		hooks["assetInfo"] += tmplOverlayAssetInfo
+		hooks["rawOpen"] += tmplOverlayRawOpen
+		hooks["nodeInfo"] = tmplOverlayNodeInfo
+		hooks["exist"] += tmplOverlayExist
+	}
+	return hooks
+}
+
 // writeTOC writes the table of contents file.
-func writeTOC(w io.Writer, keys []string, toc map[string]*asset) (err error) {
-	_, err = w.Write([]byte(tmplFuncAsset))
+func writeTOC(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	err = writeScoped(w, genScope{}.withHooks(lookupHooks(c)), tmplFuncAsset)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, tmplFilesTable)
 	if err != nil {
 		return err
 	}
@@ -168,6 +217,17 @@ func writeTOC(w io.Writer, keys []string, toc map[string]*asset) (err error) {
 	}
 
 	_, err = fmt.Fprint(w, "}\n")
+	if err != nil {
+		return err
+	}
+
+	if c.HashedNames {
+		err = writeHashedNames(w, genScope{})
+		if err != nil {
+			return err
+		}
+		err = writeHashedNamesTables(w, "", keys, toc)
+	}
 
 	return
 }
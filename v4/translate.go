@@ -5,6 +5,7 @@
 package bindata
 
 import (
+	"os"
 	"sort"
 )
 
@@ -21,6 +22,7 @@ func Translate(c *Config) (err error) {
 	scanner := newFSScanner(c)
 
 	assets := make(map[string]*asset, 0)
+	dirs := make(map[string]*asset)
 
 	// Locate all the assets.
 	for _, input := range c.Input {
@@ -35,6 +37,12 @@ func Translate(c *Config) (err error) {
 				assets[k] = asset
 			}
 		}
+		for k, dir := range scanner.dirs {
+			_, ok := dirs[k]
+			if !ok {
+				dirs[k] = dir
+			}
+		}
 
 		scanner.Reset()
 	}
@@ -46,9 +54,83 @@ func Translate(c *Config) (err error) {
 
 	sort.Strings(keys)
 
+	dirList := listDirs(c, keys, dirs)
+
+	if c.HashedNames || c.SignKey != nil {
+		err = hashAssets(c, keys, assets)
+		if err != nil {
+			return err
+		}
+	}
+
+	fdc, withDict := c.compressor.(*flateDictCompressor)
+
+	if withDict && !c.Debug && !c.Dev {
+		fdc.dict, err = buildDict(keys, assets)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.Debug && !c.Dev {
+		err = chooseCompression(c, keys, assets)
+		if err != nil {
+			return err
+		}
+	}
+
+	if withDict && c.Verbose && !c.Debug && !c.Dev {
+		err = reportDict(os.Stderr, c, fdc, keys, assets)
+		if err != nil {
+			return err
+		}
+	}
+
 	if c.Split {
-		return translateToDir(c, keys, assets)
+		err = translateToDir(c, keys, assets, dirList)
+	} else {
+		err = translateToFile(c, keys, assets, dirList)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(c.HashedNamesManifest) > 0 {
+		err = writeHashedNamesManifest(c.HashedNamesManifest, keys, assets)
+	}
+
+	return err
+}
+
+// listDirs return the directories sorted by name.
+// If EmptyDirs is false, only the directories that contains at least one
+// asset are returned.
+func listDirs(c *Config, keys []string, dirs map[string]*asset) (list []*asset) {
+	parents := make(map[string]struct{})
+	if !c.EmptyDirs {
+		for _, key := range keys {
+			for x := len(key) - 1; x > 0; x-- {
+				if key[x] == '/' {
+					parents[key[:x]] = struct{}{}
+				}
+			}
+		}
 	}
 
-	return translateToFile(c, keys, assets)
+	list = make([]*asset, 0, len(dirs))
+	for name, dir := range dirs {
+		if !c.EmptyDirs {
+			_, ok := parents[name]
+			if !ok {
+				continue
+			}
+		}
+		list = append(list, dir)
+	}
+
+	sort.Slice(list, func(x, y int) bool {
+		return list[x].name < list[y].name
+	})
+
+	return list
 }
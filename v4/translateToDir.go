@@ -12,8 +12,8 @@ import (
 )
 
 // translateToDir generates splited file
-func translateToDir(c *Config, keys []string, toc map[string]*asset) error {
-	if err := generateCommonFile(c, keys, toc); err != nil {
+func translateToDir(c *Config, keys []string, toc map[string]*asset, dirs []*asset) error {
+	if err := generateCommonFile(c, keys, toc, dirs); err != nil {
 		return err
 	}
 
@@ -27,7 +27,7 @@ func translateToDir(c *Config, keys []string, toc map[string]*asset) error {
 	return nil
 }
 
-func generateCommonFile(c *Config, keys []string, toc map[string]*asset) (err error) {
+func generateCommonFile(c *Config, keys []string, toc map[string]*asset, dirs []*asset) (err error) {
 	// Create output file.
 	out := filepath.Join(c.Output, DefOutputName)
 	fd, err := os.Create(out)
@@ -55,7 +55,7 @@ func generateCommonFile(c *Config, keys []string, toc map[string]*asset) (err er
 
 	// Write assets.
 	if c.Debug || c.Dev {
-		err = writeDebugHeader(bfd)
+		err = writeDebugHeader(bfd, c)
 	} else {
 		err = writeReleaseHeader(bfd, c)
 	}
@@ -64,20 +64,56 @@ func generateCommonFile(c *Config, keys []string, toc map[string]*asset) (err er
 		goto out
 	}
 
+	// Write the constants of asset names
+	if c.NameConsts {
+		err = writeNameConsts(bfd, c, keys, toc)
+		if err != nil {
+			goto out
+		}
+	}
+
 	// Write table of contents
-	err = writeTOC(bfd, keys, toc)
+	err = writeTOC(bfd, c, keys, toc)
 	if err != nil {
 		goto out
 	}
 
 	// Write hierarchical tree of assets
-	err = writeTOCTree(bfd, keys, toc)
+	err = writeTOCTree(bfd, c, keys, toc, dirs)
+	if err != nil {
+		goto out
+	}
+
+	// Write the directories information
+	err = writeNodeInfo(bfd, c, dirs)
+	if err != nil {
+		goto out
+	}
+
+	// Write the query functions
+	err = writeQuery(bfd, genScope{})
 	if err != nil {
 		goto out
 	}
 
 	// Write restore procedure
-	err = writeRestore(bfd)
+	err = writeRestore(bfd, genScope{})
+	if err != nil {
+		goto out
+	}
+
+	// Write the asset streaming reader
+	err = writeRaw(bfd, c, keys, toc)
+	if err != nil {
+		goto out
+	}
+	err = writeAssetReader(bfd, c, genScope{})
+	if err != nil {
+		goto out
+	}
+
+	// Write optional API
+	err = writeFeatures(bfd, c, keys, toc)
 
 out:
 	return flushAndClose(fd, bfd, err)
@@ -11,7 +11,7 @@ import (
 )
 
 // translateToFile generates one single file
-func translateToFile(c *Config, keys []string, toc map[string]*asset) (err error) {
+func translateToFile(c *Config, keys []string, toc map[string]*asset, dirs []*asset) (err error) {
 	// Create output file.
 	fd, err := os.Create(c.Output)
 	if err != nil {
@@ -47,20 +47,62 @@ func translateToFile(c *Config, keys []string, toc map[string]*asset) (err error
 		goto out
 	}
 
+	// Write the constants of asset names
+	if c.NameConsts {
+		err = writeNameConsts(bfd, c, keys, toc)
+		if err != nil {
+			goto out
+		}
+	}
+
+	// Write the assets tables as bundle, with its type and methods
+	if len(c.Bundle) > 0 {
+		err = writeBundle(bfd, c, keys, toc, dirs)
+		goto out
+	}
+
 	// Write table of contents
-	err = writeTOC(bfd, keys, toc)
+	err = writeTOC(bfd, c, keys, toc)
 	if err != nil {
 		goto out
 	}
 
 	// Write hierarchical tree of assets
-	err = writeTOCTree(bfd, keys, toc)
+	err = writeTOCTree(bfd, c, keys, toc, dirs)
 	if err != nil {
-		return err
+		goto out
+	}
+
+	// Write the directories information
+	err = writeNodeInfo(bfd, c, dirs)
+	if err != nil {
+		goto out
+	}
+
+	// Write the query functions
+	err = writeQuery(bfd, genScope{})
+	if err != nil {
+		goto out
 	}
 
 	// Write restore procedure
-	err = writeRestore(bfd)
+	err = writeRestore(bfd, genScope{})
+	if err != nil {
+		goto out
+	}
+
+	// Write the asset streaming reader
+	err = writeRaw(bfd, c, keys, toc)
+	if err != nil {
+		goto out
+	}
+	err = writeAssetReader(bfd, c, genScope{})
+	if err != nil {
+		goto out
+	}
+
+	// Write optional API
+	err = writeFeatures(bfd, c, keys, toc)
 out:
 	return flushAndClose(fd, bfd, err)
 }
@@ -47,6 +47,93 @@ func writeHeader(bfd io.Writer, c *Config, keys []string, toc map[string]*asset)
 	return nil
 }
 
+// writeFeatures writes the optional parts of the generated API.
+func writeFeatures(w io.Writer, c *Config, keys []string, toc map[string]*asset) (err error) {
+	if c.FS {
+		err = writeFS(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.HTTPFileSystem {
+		err = writeHTTPFileSystem(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.HTTPHandler {
+		err = writeHandler(w, c, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.Archive {
+		err = writeArchive(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.RestoreWithOptions {
+		err = writeRestoreWithOptions(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	// The verification must be written before the cache, so the asset is
+	// verified before its stored in the cache.
+	if c.Verify {
+		err = writeVerify(w, c, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.Cache {
+		err = writeCache(w, c, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	// The overlay must be written after the cache, so its init function
+	// wrap the cached assets.
+	if c.Overlay {
+		err = writeOverlay(w, c, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.ContentType {
+		err = writeContentType(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.withMeta() {
+		err = writeMeta(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if c.SignKey != nil {
+		err = writeSignature(w, c, keys, toc)
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.EncryptKey) > 0 {
+		err = writeEncrypt(w, genScope{})
+		if err != nil {
+			return err
+		}
+	}
+	if (c.Debug || c.Dev) && (c.ContentType || c.withMeta()) {
+		err = writeDebugInfo(w, c)
+		if err != nil {
+			return err
+		}
+	}
+	return writeWrap(w, c, genScope{})
+}
+
 // flushAndClose will flush the buffered writer `bfd` and close the file `fd`.
 func flushAndClose(fd io.Closer, bfd *bufio.Writer, errParam error) (err error) {
 	err = errParam
@@ -0,0 +1,194 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// addVerifyImports add the packages required by the generated function
+// VerifyAssets and the verification of asset on load.
+func addVerifyImports(c *Config, imports goImports) {
+	imports.add("context")
+	if c.Debug || c.Dev {
+		return
+	}
+	imports.add("crypto/md5", "fmt", "io", "sort")
+	if c.NoMemCopy {
+		imports.add("strings")
+	} else {
+		imports.add("bytes")
+	}
+}
+
+// writeVerify writes the function VerifyAssets in the scope s, and the
+// function that verify each asset when its loaded if VerifyOnLoad is set.
+// In debug mode, the assets are read from disk, so there is nothing to
+// verify.
+func writeVerify(w io.Writer, c *Config, s genScope) (err error) {
+	if c.Debug || c.Dev {
+		return writeScoped(w, s, tmplVerifyDebug)
+	}
+
+	hooks := map[string]string{
+		"reader": verifyRawReader(c, `{{.Field "key"}}.decrypt`),
+	}
+	err = writeScoped(w, s.withHooks(hooks), tmplVerify)
+	if err != nil {
+		return err
+	}
+	err = writeVerifyCheck(w, c)
+	if err != nil {
+		return err
+	}
+	if !c.VerifyOnLoad {
+		return nil
+	}
+	_, err = io.WriteString(w, tmplVerifyWrap)
+	return err
+}
+
+// writeVerifyCheck writes the function bindataVerify, that compare the
+// content of asset with its information.
+func writeVerifyCheck(w io.Writer, c *Config) (err error) {
+	var checkSize string
+	if !c.NoMetadata {
+		checkSize = tmplVerifySize
+	}
+	_, err = fmt.Fprintf(w, tmplVerifyCheck, checkSize)
+	return err
+}
+
+// verifyRawReader return the part of function that set the reader r to the
+// content of asset from its embedded data in raw.
+// The encrypted data is decrypted using the function decrypt.
+func verifyRawReader(c *Config, decrypt string) string {
+	var reader string
+	if len(c.EncryptKey) > 0 {
+		reader = fmt.Sprintf(tmplVerifyDecrypt, decrypt)
+	} else if c.NoMemCopy {
+		reader = fmt.Sprintf(tmplVerifyReader, "strings")
+	} else {
+		reader = fmt.Sprintf(tmplVerifyReader, "bytes")
+	}
+	if c.AdaptiveCompression {
+		return reader + tmplVerifyDecompressAdaptive
+	}
+	if !c.NoCompress {
+		return reader + tmplVerifyDecompress
+	}
+	return reader
+}
+
+const tmplVerifyDebug = `
+//
+// VerifyAssets verify the content of embedded assets against their checksum
+// and size.
+// In debug mode, the assets are read from disk, so it always return nil.
+//
+{{.Decl "VerifyAssets"}}(ctx context.Context) []error {
+	return nil
+}
+`
+
+const tmplVerify = `
+//
+// VerifyAssets decompress each embedded asset and compare its content against
+// the MD5 checksum and size that are stored when the code is generated, for
+// example to detect the binary that has been corrupted.
+// It returns the errors of all corrupted assets, sorted by asset name, or nil
+// if all assets are valid.
+// If ctx is done before all assets are verified, the context error is
+// appended to the errors.
+//
+{{.Decl "VerifyAssets"}}(ctx context.Context) (errs []error) {
+	names := make([]string, 0, len({{.Field "raw"}}))
+	for name := range {{.Field "raw"}} {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := ctx.Err()
+		if err != nil {
+			return append(errs, err)
+		}
+		err = {{.Ref "verifyRaw"}}(name, {{.Field "raw"}}[name])
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// {{.Name "verifyRaw"}} verify the embedded data of asset against its
+// information, without loading the whole content into memory.
+{{.Decl "verifyRaw"}}(name string, raw bindataRaw) error {
+{{.Hook "reader"}}
+	h := md5.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return fmt.Errorf("asset %s can't read by error: %v", name, err)
+	}
+	return bindataVerify(name, size, h.Sum(nil), raw.info)
+}
+`
+
+const tmplVerifyCheck = `
+// bindataVerify compare the size and MD5 checksum of asset content with its
+// information.
+func bindataVerify(name string, size int64, sum []byte, info fileInfoEx) error {
+%s	if got := fmt.Sprintf("%%x", sum); got != info.MD5Checksum() {
+		return fmt.Errorf("asset %%s is corrupted: MD5 checksum is %%s, expecting %%s",
+			name, got, info.MD5Checksum())
+	}
+	return nil
+}
+`
+
+const tmplVerifyReader = `	var r io.Reader = %s.NewReader(raw.data)
+`
+
+const tmplVerifyDecrypt = `	data, err := %s(raw.data, name)
+	if err != nil {
+		return err
+	}
+	var r io.Reader = bytes.NewReader(data)
+`
+
+const tmplVerifyDecompress = `	rc, err := bindataDecompress(r)
+	if err != nil {
+		return fmt.Errorf("asset %s can't read by error: %v", name, err)
+	}
+	defer rc.Close()
+	r = rc
+`
+
+const tmplVerifySize = `	if size != info.Size() {
+		return fmt.Errorf("asset %s is corrupted: size is %d, expecting %d",
+			name, size, info.Size())
+	}
+`
+
+const tmplVerifyWrap = `
+// bindataVerifyWrap return the function that verify the asset each time its
+// loaded.
+func bindataVerifyWrap(name string, f func() (*asset, error)) func() (*asset, error) {
+	return func() (*asset, error) {
+		a, err := f()
+		if err != nil {
+			return nil, err
+		}
+		sum := md5.Sum(a.bytes)
+		err = bindataVerify(name, int64(len(a.bytes)), sum[:], a.info)
+		if err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+}
+`
+
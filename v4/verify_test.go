@@ -0,0 +1,45 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"testing"
+)
+
+func TestAddVerifyImports(t *testing.T) {
+	tests := []struct {
+		desc string
+		cfg  *Config
+		exp  goImports
+	}{{
+		desc: `With debug`,
+		cfg: &Config{
+			Debug: true,
+		},
+		exp: newGoImports("context"),
+	}, {
+		desc: `With compress`,
+		cfg:  &Config{},
+		exp: newGoImports("bytes", "context", "crypto/md5", "fmt", "io",
+			"sort"),
+	}, {
+		desc: `With nocompress and nomemcopy`,
+		cfg: &Config{
+			NoCompress: true,
+			NoMemCopy:  true,
+		},
+		exp: newGoImports("context", "crypto/md5", "fmt", "io",
+			"sort", "strings"),
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		got := newGoImports()
+		addVerifyImports(test.cfg, got)
+
+		assert(t, test.exp, got, true)
+	}
+}
@@ -0,0 +1,672 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// List of generated functions whose first argument is the asset name, mapped
+// to the kind of node that the name must refer to.
+// nolint: gochecknoglobals
+var vetFuncs = map[string]int{
+	"Asset":        vetKindFile,
+	"AssetReader":  vetKindFile,
+	"MustAsset":    vetKindFile,
+	"AssetInfo":    vetKindFile | vetKindDir,
+	"AssetDir":     vetKindDir,
+	"AssetDirInfo": vetKindDir,
+}
+
+const (
+	vetKindFile = 1 << iota
+	vetKindDir
+)
+
+// VetIssue describe a call site that reference an asset that does not exist
+// in the generated file.
+type VetIssue struct {
+	Pos  token.Position
+	Func string
+	Name string
+}
+
+// String return the issue in the format of "file:line:column: message".
+func (issue VetIssue) String() string {
+	return fmt.Sprintf("%s: %s(%q): asset does not exist", issue.Pos,
+		issue.Func, issue.Name)
+}
+
+// VetResult contains the result of checking the call sites of the generated
+// functions against the assets in the generated file.
+type VetResult struct {
+	// Missing contains the call sites whose constant argument is not the
+	// name of an embedded asset.
+	Missing []VetIssue
+
+	// Unused contains the name of embedded assets that is not referenced
+	// by any call site, directly or through AssetDir on its parent
+	// directory.
+	Unused []string
+
+	// Dynamic contains the position of call sites whose argument is not a
+	// constant and can not be checked.
+	// If its not empty, some assets in Unused may be used at run time.
+	Dynamic []token.Position
+}
+
+// vetTable contains the asset and directory names of a _bindata table.
+type vetTable struct {
+	files map[string]bool
+	dirs  map[string]bool
+}
+
+// vetFile contains the references to the generated packages in the file
+// being checked.
+type vetFile struct {
+	// local is true if the file is in the same package with one of
+	// the generated files, or its dot-imported, so the generated
+	// functions can be called without package name.
+	local bool
+
+	// pkgNames contains the names of imported generated packages.
+	pkgNames map[string]bool
+
+	// pkgConsts contains the constants of imported generated packages, by
+	// their package name in the file.
+	pkgConsts map[string]map[string]string
+}
+
+// vetter implement the checker of call sites.
+type vetter struct {
+	fset      *token.FileSet
+	tables    map[string]*vetTable
+	pkgs      map[string]string
+	pkgDirs   map[string]bool
+	consts    map[string]string
+	pkgConsts map[string]map[string]string
+	skipped   map[string]bool
+	used      map[string]bool
+	usedDir   map[string]bool
+	res       *VetResult
+}
+
+//
+// Vet check the calls of Asset, MustAsset, AssetReader, AssetInfo, AssetDir
+// and AssetDirInfo with constant argument, in the Go files inside dirs,
+// against the assets in the generated files.
+// If the directory end with "/...", its sub directories is also checked,
+// except testdata, vendor, and the one whose name begin with "." or "_".
+//
+// Only the calls that resolve to the generated packages are checked: the
+// qualified call through the import path of the generated package, or the
+// unqualified call inside the same package.
+// The import path is resolved using the go.mod file in the parent
+// directories of generated file, or the GOPATH layout.
+// The call to method with the same name on another type is ignored.
+//
+// The call through the variable of bundle, for example "WebAssets.Asset",
+// is checked against the assets of that bundle only, while the other calls
+// are checked against the assets of all the generated files.
+//
+func Vet(generated []string, dirs []string) (res *VetResult, err error) {
+	v := &vetter{
+		fset:      token.NewFileSet(),
+		tables:    make(map[string]*vetTable),
+		pkgs:      make(map[string]string),
+		pkgDirs:   make(map[string]bool),
+		consts:    make(map[string]string),
+		pkgConsts: make(map[string]map[string]string),
+		skipped:   make(map[string]bool),
+		used:      make(map[string]bool),
+		usedDir:   make(map[string]bool),
+		res:       &VetResult{},
+	}
+
+	for _, name := range generated {
+		err = v.parseGenerated(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dir := range dirs {
+		err = v.walk(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	v.collectUnused()
+
+	return v.res, nil
+}
+
+// parseGenerated read the _bindata tables and the constants from the
+// generated file.
+func (v *vetter) parseGenerated(name string) (err error) {
+	f, err := parser.ParseFile(v.fset, name, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+	v.skipped[abs] = true
+	v.pkgDirs[filepath.Dir(abs)] = true
+
+	importPath := vetImportPath(filepath.Dir(abs))
+	if len(importPath) > 0 {
+		v.pkgs[importPath] = f.Name.Name
+	}
+
+	pkgConsts := v.pkgConsts[importPath]
+	if pkgConsts == nil {
+		pkgConsts = make(map[string]string)
+		v.pkgConsts[importPath] = pkgConsts
+	}
+
+	for key, val := range fileConsts(f, v.consts, nil) {
+		v.consts[key] = val
+		pkgConsts[key] = val
+	}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vspec := spec.(*ast.ValueSpec)
+			if len(vspec.Names) != 1 || len(vspec.Values) != 1 {
+				continue
+			}
+			v.parseTable(vspec.Names[0].Name, vspec.Values[0])
+		}
+	}
+
+	return nil
+}
+
+// parseTable add the keys of the _bindata and _bindataDirs table into the
+// table of bundle.
+func (v *vetter) parseTable(varName string, value ast.Expr) {
+	lit, ok := value.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	mapType, ok := lit.Type.(*ast.MapType)
+	if !ok {
+		return
+	}
+
+	var bundle string
+	_, isTOC := mapType.Value.(*ast.FuncType)
+	isDirs := false
+
+	switch {
+	case isTOC && strings.HasPrefix(varName, "_bindata"):
+		bundle = strings.TrimPrefix(varName, "_bindata")
+	case strings.HasPrefix(varName, "_bindataDirs"):
+		bundle = strings.TrimPrefix(varName, "_bindataDirs")
+		isDirs = true
+	default:
+		return
+	}
+
+	table := v.tables[bundle]
+	if table == nil {
+		table = &vetTable{
+			files: make(map[string]bool),
+			dirs:  map[string]bool{"": true},
+		}
+		v.tables[bundle] = table
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := evalString(kv.Key, nil, nil)
+		if !ok {
+			continue
+		}
+		if isDirs {
+			table.dirs[key] = true
+			continue
+		}
+		table.files[key] = true
+		for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			table.dirs[dir] = true
+		}
+	}
+}
+
+// walk check the Go files in the directory, and its sub directories if the
+// directory end with "/...".
+func (v *vetter) walk(dir string) (err error) {
+	if !strings.HasSuffix(dir, "...") {
+		return v.checkDir(dir)
+	}
+
+	root := filepath.Clean(strings.TrimSuffix(dir, "..."))
+
+	return filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return nil
+		}
+		name := fi.Name()
+		if p != root && (name == "testdata" || name == "vendor" ||
+			strings.HasPrefix(name, ".") ||
+			strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		return v.checkDir(p)
+	})
+}
+
+// checkDir check the Go files in the directory.
+func (v *vetter) checkDir(dir string) (err error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	files := make([]*ast.File, 0, len(matches))
+	vfs := make([]*vetFile, 0, len(matches))
+	consts := make(map[string]string)
+
+	for _, name := range matches {
+		abs, err := filepath.Abs(name)
+		if err != nil {
+			return err
+		}
+		if v.skipped[abs] {
+			continue
+		}
+
+		f, err := parser.ParseFile(v.fset, name, nil, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		vfs = append(vfs, v.newVetFile(f, v.pkgDirs[absDir]))
+	}
+
+	// The constant may reference the constant in another file of the
+	// same package, so collect them from all files before resolving
+	// the call sites.
+	for key, val := range v.consts {
+		consts[key] = val
+	}
+	for x, f := range files {
+		for key, val := range fileConsts(f, consts, vfs[x].pkgConsts) {
+			consts[key] = val
+		}
+	}
+
+	for x, f := range files {
+		vf := vfs[x]
+		if !vf.local && len(vf.pkgNames) == 0 {
+			continue
+		}
+		ast.Inspect(f, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if ok {
+				v.checkCall(call, consts, vf)
+			}
+			return true
+		})
+	}
+
+	return nil
+}
+
+// newVetFile return the references to the generated packages from the
+// imports of file.
+func (v *vetter) newVetFile(f *ast.File, local bool) (vf *vetFile) {
+	vf = &vetFile{
+		local:     local,
+		pkgNames:  make(map[string]bool),
+		pkgConsts: make(map[string]map[string]string),
+	}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		pkgName, ok := v.pkgs[importPath]
+		if !ok {
+			continue
+		}
+		if spec.Name != nil {
+			pkgName = spec.Name.Name
+		}
+		switch pkgName {
+		case "_":
+		case ".":
+			vf.local = true
+		default:
+			vf.pkgNames[pkgName] = true
+			vf.pkgConsts[pkgName] = v.pkgConsts[importPath]
+		}
+	}
+	return vf
+}
+
+// checkCall check the argument of a call to one of the generated
+// functions.
+func (v *vetter) checkCall(call *ast.CallExpr, consts map[string]string, vf *vetFile) {
+	var (
+		funcName string
+		recv     ast.Expr
+	)
+
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		if !vf.local {
+			return
+		}
+		funcName = fn.Name
+	case *ast.SelectorExpr:
+		funcName = fn.Sel.Name
+		recv = fn.X
+	default:
+		return
+	}
+
+	kind, ok := vetFuncs[funcName]
+	if !ok || len(call.Args) != 1 {
+		return
+	}
+	recv, ok = v.resolveRecv(recv, vf)
+	if !ok {
+		return
+	}
+
+	name, ok := evalString(call.Args[0], consts, vf.pkgConsts)
+	if !ok {
+		v.res.Dynamic = append(v.res.Dynamic, v.fset.Position(call.Pos()))
+		return
+	}
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if kind&vetKindDir != 0 && v.hasDir(recv, name) {
+		// AssetInfo on directory does not read its assets.
+		if kind&vetKindFile == 0 {
+			v.usedDir[name] = true
+		}
+		return
+	}
+	if kind&vetKindFile != 0 && v.hasFile(recv, name) {
+		v.used[name] = true
+		return
+	}
+
+	v.res.Missing = append(v.res.Missing, VetIssue{
+		Pos:  v.fset.Position(call.Args[0].Pos()),
+		Func: funcName,
+		Name: name,
+	})
+}
+
+// resolveRecv return the bundle variable of receiver, or nil if the
+// receiver is the name of generated package.
+// It returns false if the receiver does not reference the generated
+// package, for example the method with the same name on another type.
+func (v *vetter) resolveRecv(recv ast.Expr, vf *vetFile) (ast.Expr, bool) {
+	switch x := recv.(type) {
+	case nil:
+		return nil, true
+	case *ast.Ident:
+		if vf.pkgNames[x.Name] {
+			return nil, true
+		}
+		if vf.local && v.tables[x.Name] != nil {
+			return x, true
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if ok && vf.pkgNames[pkg.Name] && v.tables[x.Sel.Name] != nil {
+			return x, true
+		}
+	}
+	return nil, false
+}
+
+// bundleTable return the table of bundle, if the receiver of call is one of
+// the bundle variables, for example "WebAssets" in "WebAssets.Asset" or
+// "assets.WebAssets.Asset".
+func (v *vetter) bundleTable(recv ast.Expr) *vetTable {
+	var name string
+
+	switch x := recv.(type) {
+	case *ast.Ident:
+		name = x.Name
+	case *ast.SelectorExpr:
+		name = x.Sel.Name
+	default:
+		return nil
+	}
+	if len(name) == 0 {
+		return nil
+	}
+	return v.tables[name]
+}
+
+func (v *vetter) hasFile(recv ast.Expr, name string) bool {
+	table := v.bundleTable(recv)
+	if table != nil {
+		return table.files[name]
+	}
+	for _, table = range v.tables {
+		if table.files[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *vetter) hasDir(recv ast.Expr, name string) bool {
+	table := v.bundleTable(recv)
+	if table != nil {
+		return table.dirs[name]
+	}
+	for _, table = range v.tables {
+		if table.dirs[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// collectUnused set the list of assets that is not referenced by any call
+// site.
+func (v *vetter) collectUnused() {
+	seen := make(map[string]bool)
+
+	for _, table := range v.tables {
+		for name := range table.files {
+			if seen[name] || v.used[name] || v.isUsedByDir(name) {
+				continue
+			}
+			seen[name] = true
+			v.res.Unused = append(v.res.Unused, name)
+		}
+	}
+
+	sort.Strings(v.res.Unused)
+}
+
+// isUsedByDir return true if one of parent directories of asset is
+// referenced by AssetDir or AssetDirInfo.
+func (v *vetter) isUsedByDir(name string) bool {
+	if v.usedDir[""] {
+		return true
+	}
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if v.usedDir[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// vetImportPath return the import path of package in the directory dir,
+// using the module path in the go.mod file of its parent directories, or
+// the path under the "src" directory of GOPATH.
+// It returns empty string if the import path can not be resolved.
+func vetImportPath(dir string) string {
+	for p := dir; ; p = filepath.Dir(p) {
+		content, err := ioutil.ReadFile(filepath.Join(p, "go.mod"))
+		if err == nil {
+			modPath := vetModulePath(content)
+			if len(modPath) == 0 {
+				return ""
+			}
+			rel, err := filepath.Rel(p, dir)
+			if err != nil {
+				return ""
+			}
+			if rel == "." {
+				return modPath
+			}
+			return modPath + "/" + filepath.ToSlash(rel)
+		}
+		if filepath.Dir(p) == p {
+			break
+		}
+	}
+
+	sep := string(filepath.Separator) + "src" + string(filepath.Separator)
+	x := strings.LastIndex(dir, sep)
+	if x < 0 {
+		return ""
+	}
+	return filepath.ToSlash(dir[x+len(sep):])
+}
+
+// vetModulePath return the module path in the content of go.mod file.
+func vetModulePath(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+		modPath, err := strconv.Unquote(fields[1])
+		if err != nil {
+			modPath = fields[1]
+		}
+		return modPath
+	}
+	return ""
+}
+
+// fileConsts return the package-level string constants declared in the file.
+// The known constants is used to resolve the constant that reference
+// another constant, and the pkgConsts to resolve the constant of imported
+// generated package.
+func fileConsts(f *ast.File, known map[string]string, pkgConsts map[string]map[string]string) map[string]string {
+	consts := make(map[string]string)
+
+	lookup := make(map[string]string, len(known))
+	for key, val := range known {
+		lookup[key] = val
+	}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vspec := spec.(*ast.ValueSpec)
+			if len(vspec.Names) != len(vspec.Values) {
+				continue
+			}
+			for x, ident := range vspec.Names {
+				val, ok := evalString(vspec.Values[x], lookup, pkgConsts)
+				if !ok {
+					continue
+				}
+				consts[ident.Name] = val
+				lookup[ident.Name] = val
+			}
+		}
+	}
+
+	return consts
+}
+
+// evalString return the value of constant string expression.
+// The expression can be a string literal, the name of known constant, the
+// name of constant in one of pkgConsts qualified by its package name, or the
+// concatenation of them.
+// The constant qualified by the other package can not be resolved.
+func evalString(expr ast.Expr, consts map[string]string, pkgConsts map[string]map[string]string) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return "", false
+		}
+		val, err := strconv.Unquote(x.Value)
+		if err != nil {
+			return "", false
+		}
+		return val, true
+
+	case *ast.Ident:
+		val, ok := consts[x.Name]
+		return val, ok
+
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		val, ok := pkgConsts[pkg.Name][x.Sel.Name]
+		return val, ok
+
+	case *ast.ParenExpr:
+		return evalString(x.X, consts, pkgConsts)
+
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return "", false
+		}
+		left, ok := evalString(x.X, consts, pkgConsts)
+		if !ok {
+			return "", false
+		}
+		right, ok := evalString(x.Y, consts, pkgConsts)
+		if !ok {
+			return "", false
+		}
+		return left + right, true
+	}
+
+	return "", false
+}
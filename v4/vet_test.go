@@ -0,0 +1,62 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"testing"
+)
+
+func TestVet(t *testing.T) {
+	tests := []struct {
+		desc       string
+		generated  []string
+		dirs       []string
+		expMissing []string
+		expUnused  []string
+		expDynamic int
+	}{{
+		desc: `With recursive directory`,
+		generated: []string{
+			"internal/tests/withNameConsts/bindata.exp",
+		},
+		dirs: []string{"testdata/vet/single/..."},
+		expMissing: []string{
+			`testdata/vet/single/main.go:19:24: MustAsset("in/x"): asset does not exist`,
+			`testdata/vet/single/main.go:22:26: AssetDir("in/file name"): asset does not exist`,
+			`testdata/vet/single/sub/sub.go:10:27: AssetInfo("in/d"): asset does not exist`,
+		},
+		expUnused:  []string{"in/test.asset"},
+		expDynamic: 2,
+	}, {
+		desc: `With bundles`,
+		generated: []string{
+			"internal/tests/withBundle/bindata.exp",
+			"internal/tests/withBundle/bindata_doc.exp",
+		},
+		dirs: []string{"testdata/vet/bundle"},
+		expMissing: []string{
+			`testdata/vet/bundle/main.go:9:33: Asset("in/b/test.asset"): asset does not exist`,
+		},
+		expUnused: []string{"in/c/test.asset", "in/file name"},
+	}}
+
+	for _, test := range tests {
+		t.Log(test.desc)
+
+		res, err := Vet(test.generated, test.dirs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var gotMissing []string
+		for _, issue := range res.Missing {
+			gotMissing = append(gotMissing, issue.String())
+		}
+
+		assert(t, test.expMissing, gotMissing, true)
+		assert(t, test.expUnused, res.Unused, true)
+		assert(t, test.expDynamic, len(res.Dynamic), true)
+	}
+}
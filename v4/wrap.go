@@ -0,0 +1,86 @@
+// Copyright 2021 The go-bindata Authors. All rights reserved.
+// Use of this source code is governed by a CC0 1.0 Universal (CC0 1.0)
+// Public Domain Dedication license that can be found in the LICENSE file.
+
+package bindata
+
+import (
+	"io"
+)
+
+// withWrap return true if the asset functions are wrapped, by the cache,
+// overlay, or verification, when the program start.
+// In debug mode with SignKey, the asset functions are copied before they
+// are wrapped, so VerifySignature read the asset that is embedded.
+func (c *Config) withWrap() bool {
+	if c.Overlay {
+		return true
+	}
+	if c.Debug || c.Dev {
+		return c.SignKey != nil
+	}
+	return c.Cache || c.VerifyOnLoad
+}
+
+// writeWrap writes the function that replace each asset function with the
+// one that verify, cache, and read the asset from overlay directory, in that
+// order, in the scope s.
+// In package scope, the asset functions are replaced by the init function,
+// while in bundle scope, they are replaced by the method wrap of Bundle.
+func writeWrap(w io.Writer, c *Config, s genScope) error {
+	if !c.withWrap() {
+		return nil
+	}
+
+	hooks := make(map[string]string)
+	if c.Debug || c.Dev {
+		if c.SignKey != nil {
+			hooks["init"] = tmplWrapSignTOCInit
+			hooks["wrap"] = "\t\t{{.Field \"signTOC\"}}[name] = f\n"
+		}
+	} else {
+		if c.VerifyOnLoad {
+			hooks["wrap"] += "\t\tf = bindataVerifyWrap(name, f)\n"
+		}
+		if c.Cache {
+			hooks["wrap"] += "\t\tf = {{.Field \"cache\"}}.wrap(name, f)\n"
+		}
+	}
+	if c.Overlay {
+		hooks["wrap"] += "\t\tf = {{.Field \"overlay\"}}.wrap(name, f)\n"
+	}
+
+	return writeScoped(w, s.withHooks(hooks), tmplWrap)
+}
+
+// tmplWrapSignTOCInit is the part of wrap function that create the copy of
+// asset functions, before they are wrapped.
+const tmplWrapSignTOCInit = `	{{.Field "signTOC"}} = make(map[string]func() (*asset, error), len({{.Field "files"}}))
+`
+
+const tmplWrap = `{{if .Bundle}}
+// wrap replace the asset functions of bundle, and the asset functions in its
+// tree, with the wrapped ones.
+func (b *Bundle) wrap() {
+{{- else}}
+// init replace the asset functions in _bindata, and the asset functions in
+// _bintree, with the wrapped ones.
+func init() {
+{{- end}}
+{{.Hook "init"}}	for name, f := range {{.Field "files"}} {
+{{.Hook "wrap"}}		{{.Field "files"}}[name] = f
+	}
+	{{.Ref "syncTree"}}({{.Field "tree"}}, nil)
+}
+
+// {{.Name "syncTree"}} replace the asset function in node and its children
+// with the one in the table of assets.
+{{.Decl "syncTree"}}(node *bintree, route []string) {
+	if node.Func != nil {
+		node.Func = {{.Field "files"}}[strings.Join(route, "/")]
+	}
+	for childName, child := range node.Children {
+		{{.Ref "syncTree"}}(child, append(route, childName))
+	}
+}
+`